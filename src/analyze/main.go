@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+
+	"github.com/turing-complete/laboratory/src/internal/command"
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/database"
+	"github.com/turing-complete/laboratory/src/internal/solution"
+)
+
+var (
+	approximateFile = flag.String("approximate", "", "an output of `approximate` (required)")
+	outputFile      = flag.String("o", "", "an output file (required)")
+)
+
+func main() {
+	command.Run(function)
+}
+
+func function(config *config.Config) error {
+	if !config.Solution.Aleatory {
+		return errors.New("the analysis requires an aleatory surrogate")
+	}
+
+	approximate, err := database.Open(*approximateFile)
+	if err != nil {
+		return err
+	}
+	defer approximate.Close()
+
+	output, err := database.Create(*outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	surrogate := new(solution.Surrogate)
+	if err = approximate.Get("surrogate", surrogate); err != nil {
+		return err
+	}
+
+	ni, no := surrogate.Inputs, surrogate.Outputs
+
+	solution, err := solution.New(ni, no, &config.Solution)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Analyzing the surrogate model with %d nodes...\n", surrogate.Nodes)
+
+	analysis, err := solution.Analyze(surrogate)
+	if err != nil {
+		return err
+	}
+
+	if err := output.Put("mean", analysis.Mean, no); err != nil {
+		return err
+	}
+	if err := output.Put("variance", analysis.Variance, no); err != nil {
+		return err
+	}
+	if err := output.Put("firstOrder", analysis.FirstOrder, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("total", analysis.Total, no, ni); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"log"
 
+	"github.com/ready-steady/hdf5"
 	"github.com/turing-complete/laboratory/src/internal/command"
 	"github.com/turing-complete/laboratory/src/internal/config"
 	"github.com/turing-complete/laboratory/src/internal/database"
@@ -15,6 +16,7 @@ import (
 
 var (
 	outputFile = flag.String("o", "", "an output file (required)")
+	resume     = flag.Bool("resume", false, "a flag for reusing the evaluations recorded in the output file")
 )
 
 func main() {
@@ -22,11 +24,37 @@ func main() {
 }
 
 func function(config *config.Config) error {
-	output, err := database.Create(*outputFile)
-	if err != nil {
-		return err
+	var record *solution.Record
+	if *resume {
+		input, err := database.Open(*outputFile)
+		if err != nil {
+			return err
+		}
+		record = &solution.Record{}
+		err = input.Get("record", record)
+		input.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if !*resume {
+		output, err := database.Create(*outputFile)
+		if err != nil {
+			return err
+		}
+		output.Close()
+	}
+
+	// The output file is replaced at each save since the previous record
+	// cannot be overwritten in place.
+	var latest *solution.Record
+	save := func(record *solution.Record) error {
+		latest = record
+		return database.Replace(*outputFile, func(output *hdf5.File) error {
+			return output.Put("record", *record)
+		})
 	}
-	defer output.Close()
 
 	system, err := system.New(&config.System)
 	if err != nil {
@@ -74,13 +102,23 @@ func function(config *config.Config) error {
 		log.Println("Constructing an epistemic surrogate...")
 	}
 
-	surrogate := solution.Compute(target, reference)
-
-	log.Println("Surrogate", surrogate)
+	if record != nil {
+		log.Printf("Reusing the evaluations of %d steps...\n", record.Steps)
+	}
 
-	if err := output.Put("surrogate", *surrogate); err != nil {
+	surrogate, err := solution.Compute(target, reference, record, save)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	log.Println("Surrogate", surrogate)
+
+	return database.Replace(*outputFile, func(output *hdf5.File) error {
+		if latest != nil {
+			if err := output.Put("record", *latest); err != nil {
+				return err
+			}
+		}
+		return output.Put("surrogate", *surrogate)
+	})
 }
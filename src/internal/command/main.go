@@ -9,10 +9,12 @@ import (
 	"runtime/pprof"
 
 	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/system"
 )
 
 var (
 	configFile  = flag.String("c", "", "a configuration file (required)")
+	exportFile  = flag.String("export", "", "an output file for the system in JSON instead of running")
 	profileFile = flag.String("p", "", "an output file for profiling information")
 	verbose     = flag.Bool("v", false, "a flag for displaying diagnostic information")
 )
@@ -50,11 +52,26 @@ func Run(function func(*config.Config) error) {
 		log.SetOutput(null{})
 	}
 
+	if len(*exportFile) > 0 {
+		if err = export(config, *exportFile); err != nil {
+			fail(err)
+		}
+		return
+	}
+
 	if err = function(config); err != nil {
 		fail(err)
 	}
 }
 
+func export(config *config.Config, path string) error {
+	system, err := system.New(&config.System)
+	if err != nil {
+		return err
+	}
+	return system.Export(path)
+}
+
 func fail(err error) {
 	fmt.Printf("Error: %s.\n", err)
 	os.Exit(1)
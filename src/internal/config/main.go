@@ -27,29 +27,159 @@ type Config struct {
 
 // System is a configuration of the system.
 type System struct {
-	// The TGFF file describing the platform and application.
+	// The file describing the platform and application.
 	Specification string
+	// The format of the specification, which is either “tgff,” “json,” or
+	// “dot.” If empty, the format is inferred from the file extension.
+	Format string
+	// The JSON or TGFF file describing the platform. Applicable only to
+	// “dot,” which describes only the application.
+	Platform string
+	// The scheduling policy, which is either “static,” “list,” or
+	// “earliest-finish-time.” The static policy keeps the mapping and order of
+	// the reference schedule and only recomputes the start times of tasks. The
+	// list policy reschedules the tasks using the priorities of the reference
+	// schedule, and the earliest-finish-time policy dispatches at each step
+	// the task that can finish the earliest. The default is “static.” Only
+	// the static policy can be used with operating points assigned to tasks.
+	Policy string
 	// The static-power model.
 	StaticPower StaticPower
+	// The thermal simulation.
+	Thermal Thermal
+	// The dynamic voltage and frequency scaling.
+	DVFS DVFS
 
 	temperature.Config
 }
 
+// DVFS is a configuration of dynamic voltage and frequency scaling. The
+// execution times of tasks scale inversely with frequency, the dynamic power
+// with the square of voltage times frequency, and the static power according
+// to the static-power model, which is linear in voltage except for “bsim.”
+//
+// The platform in the specification has no notion of core types; each core
+// has its own tables of execution times and power. Hence, the operating points
+// are given for sets of cores, and a core type is expressed as the set of
+// cores of that type.
+type DVFS struct {
+	// The operating points of groups of cores. Later groups override earlier
+	// ones. Cores without operating points have only the nominal one.
+	Levels []Levels
+	// The operating point of each task, which refers to the levels of the core
+	// the task is mapped to. If empty, all tasks run at the nominal levels.
+	Assignment []uint
+}
+
+// Levels is a configuration of the operating points of a group of cores.
+type Levels struct {
+	// The cores.
+	Cores string // ⊂ {0, …, #cores-1}
+	// The supply voltages of the levels.
+	Voltage []float64
+	// The frequencies of the levels.
+	Frequency []float64
+	// The level at which the execution times and power in the specification
+	// are given.
+	Nominal uint
+}
+
+// Thermal is a configuration of the thermal simulation.
+type Thermal struct {
+	// The mode, which is either “steady” or “transient.” In the former case,
+	// the periodic steady-state solution is computed. In the latter, a number
+	// of iterations of the application is simulated starting from the initial
+	// state. The default is “steady.”
+	Mode string
+	// The number of iterations of the application. Applicable only to
+	// “transient.” The default value is one.
+	Iterations uint
+	// The initial temperature in K of either each core or each thermal node.
+	// The nodes that are not cores start at the ambient temperature in the
+	// former case. Applicable only to “transient.” If empty, the ambient
+	// temperature is used.
+	Initial []float64
+}
+
 // StaticPower is a configuration of the static-power model.
 type StaticPower struct {
-	// The portion of the total power ascribed to the static power.
+	// The leakage model, which is either “table,” “exponential,”
+	// “polynomial,” or “bsim.” The default is “table.”
+	Model string
+	// The portion of the total power ascribed to the static power. If it is
+	// zero, the static power is disabled; otherwise, the model should be fully
+	// specified, and an incomplete model is an error rather than a zero static
+	// power.
 	Contribution float64 // ∈ [0, 1)
-	// The temperature values for fitting.
+	// The temperature values for fitting. Applicable only to “table.”
 	Temperature []float64
-	// The coefficient of proportionality for fitting.
+	// The coefficients of the model. For “table,” these are the coefficients
+	// of proportionality for fitting. For “exponential,” this is the exponent
+	// in 1/K. For “polynomial,” these are the coefficients of the powers of
+	// the difference between the temperature and the reference temperature,
+	// starting from the constant term. For “bsim,” these are the slope in K/V
+	// and threshold in K of the exponent of the subthreshold current.
 	Coefficient []float64
+	// The temperature in K at which the static power is equal to its nominal
+	// value. Applicable to all models except “table.”
+	Reference float64
+	// The nominal supply voltage in V. Applicable only to “bsim.”
+	Voltage float64
 }
 
 // Quantity is a configuration of the quantity of interest.
 type Quantity struct {
 	// The name of the quantity. The options are “end-to-end-delay,”
-	// “total-energy,” and “maximum-temperature.”
+	// “total-energy,” “maximum-temperature,” “core-temperature,”
+	// “mean-time-to-failure,” “finish-time,” “deadline-slack,” “peak-power,”
+	// “core-peak-power,” “average-power,” “power-density-gradient,” and
+	// “composite.” The power-density gradient requires the floorplan to have
+	// a block named “core<i>” for each core i.
 	Name string
+	// The metrics evaluated by “composite.” The options are
+	// “end-to-end-delay,” “total-energy,” “maximum-temperature,” and
+	// “energy-delay-product.”
+	Names []string
+	// The tasks whose finish times or slacks should be computed. Applicable
+	// only to “finish-time” and “deadline-slack.” If empty, all tasks are
+	// considered in the former case and all tasks with deadlines in the latter.
+	Tasks string // ⊂ {0, …, #tasks-1}
+	// The factor converting the deadlines to the units of execution times.
+	// Applicable only to “deadline-slack.” The default value is one.
+	DeadlineScale float64
+	// The number of points of the temperature trace of each core, which are
+	// evenly spread over the time span of the schedule. Applicable only to
+	// “core-temperature.” If zero, only the peak temperatures are computed.
+	Points uint
+	// The reliability model. Applicable only to “mean-time-to-failure.”
+	Reliability Reliability
+}
+
+// Reliability is a configuration of the reliability model. A failure
+// mechanism with a zero coefficient is not taken into account.
+type Reliability struct {
+	// The coefficient of the Coffin–Manson equation for thermal cycling.
+	CyclingCoefficient float64
+	// The Coffin–Manson exponent.
+	CyclingExponent float64
+	// The portion of the temperature range in the elastic region in K.
+	CyclingThreshold float64
+	// The activation energy of thermal cycling in eV.
+	CyclingEnergy float64
+
+	// The coefficient of Black’s equation for electromigration.
+	MigrationCoefficient float64
+	// The current density.
+	MigrationCurrent float64
+	// The current-density exponent.
+	MigrationExponent float64
+	// The activation energy of electromigration in eV.
+	MigrationEnergy float64
+
+	// The upper bound of the mean time to failure, which is also the value
+	// reported when no damage is accumulated, for instance, when all cycles
+	// are within the elastic region and electromigration is disabled.
+	Lifetime float64 // > 0
 }
 
 // Uncertainty is a configuration of the probability model.
@@ -58,12 +188,79 @@ type Uncertainty struct {
 	Tasks string // ⊂ {0, …, #tasks-1}
 	// The marginal distributions of tasks’ delays.
 	Distribution string
+	// The marginal distributions of particular groups of tasks, which take
+	// precedence over Distribution. Later groups override earlier ones.
+	Marginals []Marginal
 	// The multiplier used to calculate the range of deviation.
 	Deviation float64 // ≥ 0
+	// The ranges of deviation of particular groups of tasks, which take
+	// precedence over Deviation. Later groups override earlier ones.
+	Deviations []Deviation
 	// The strength of correlations between tasks.
 	Correlation float64 // > 0
+	// The correlation kernel, which is either “exponential,”
+	// “squared-exponential,” “matern-32,” “matern-52,” or
+	// “rational-quadratic.” The default is “squared-exponential.”
+	Kernel string
+	// The shape parameter of the rational-quadratic kernel.
+	Shape float64 // > 0
+	// The metric of the distance between tasks, which is either “layout,”
+	// “hop,” “undirected-hop,” “communication,” or “mapping.” The default is
+	// “layout.”
+	Metric string
+	// The file containing the correlation matrix of tasks, which takes
+	// precedence over Correlation, Kernel, and Metric. The matrix is either
+	// of size #tasks or of size #uncertain tasks.
+	Matrix string
+	// The flag for replacing the correlation matrix with the nearest positive
+	// definite one if needed.
+	Repair bool
 	// The portion of the variance to be preserved.
 	Variance float64 // ∈ (0, 1]
+	// The copula, which is either “Gaussian(),” “Student(ν),” or “Clayton(θ).”
+	// The default is “Gaussian().” The Clayton copula cannot be combined with
+	// Correlation, Kernel, Metric, Matrix, or Variance.
+	Copula string
+
+	// The multipliers of the dynamic power of tasks.
+	DynamicPower Variation
+	// The multipliers of the static power of cores.
+	StaticPower Variation
+}
+
+// Variation is a configuration of a set of independent uncertain multipliers.
+type Variation struct {
+	// The tasks or cores whose multipliers should be considered as uncertain.
+	Index string // ⊂ {0, …, #tasks-1} or ⊂ {0, …, #cores-1}
+	// The marginal distribution of the multipliers.
+	Distribution string
+	// The deviation of the multipliers from one.
+	Deviation float64 // ∈ (0, 1)
+}
+
+// Marginal is a configuration of the marginal distribution of a group of tasks.
+type Marginal struct {
+	// The tasks whose marginal distributions are being specified.
+	Tasks string // ⊂ Uncertainty.Tasks
+	// The marginal distribution of the tasks’ delays.
+	Distribution string
+}
+
+// Deviation is a configuration of the range of deviation of a group of tasks.
+// The multipliers are relative to the reference execution times, and the
+// bounds are absolute values, which take precedence over the multipliers. The
+// fields that are not specified fall back to Uncertainty.Deviation.
+type Deviation struct {
+	// The tasks whose ranges are being specified.
+	Tasks string // ⊂ Uncertainty.Tasks
+	// The multiplier used to calculate the lower bound.
+	Lower *float64 // ≥ 0
+	// The multiplier used to calculate the upper bound.
+	Upper *float64 // ≥ 0
+	// The lower bound.
+	Minimum *float64 // ≥ 0
+	// The upper bound.
+	Maximum *float64 // ≥ Minimum
 }
 
 // Solution is a configuration of the approximation algorithm.
@@ -87,6 +284,11 @@ type Solution struct {
 	RelativeError float64
 	// The tolerance of the score error.
 	ScoreError float64
+	// The number of steps between saving the record of the evaluations of the
+	// quantity made so far. An interrupted construction can be repeated with
+	// the record at hand, in which case the recorded nodes are not evaluated
+	// again. If zero, no record is saved.
+	Record uint
 }
 
 // Assessment is a configuration of the assessment procedure.
@@ -95,6 +297,9 @@ type Assessment struct {
 	Seed int64
 	// The number of samples to draw.
 	Samples uint
+	// The sampling method, which is either “sobol,” “scrambled-sobol,”
+	// “halton,” “latin-hypercube,” or “monte-carlo.” The default is “sobol.”
+	Sampler string
 }
 
 func New(path string) (*Config, error) {
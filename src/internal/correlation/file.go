@@ -0,0 +1,211 @@
+package correlation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ready-steady/linear/decomposition"
+	"github.com/turing-complete/laboratory/src/internal/database"
+)
+
+const (
+	matrixEpsilon    = 1e-10
+	repairEpsilon    = 1e-6
+	repairTolerance  = 1e-12
+	repairIterations = 10000
+)
+
+// Load reads a square matrix from a CSV or HDF5 file. In the HDF5 case, the
+// matrix is expected to be stored in a dataset called “correlation.”
+func Load(path string) ([]float64, uint, error) {
+	var data []float64
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".h5", ".hdf5":
+		file, err := database.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer file.Close()
+		if err := file.Get("correlation", &data); err != nil {
+			return nil, 0, err
+		}
+	default:
+		var err error
+		if data, err = loadCSV(path); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	n := uint(math.Sqrt(float64(len(data))) + 0.5)
+	if n == 0 || n*n != uint(len(data)) {
+		return nil, 0, errors.New(fmt.Sprintf("the matrix in the file “%s” should be square", path))
+	}
+
+	return data, n, nil
+}
+
+// Select extracts the submatrix corresponding to a set of indices.
+func Select(R []float64, n uint, index []uint) []float64 {
+	nd := uint(len(index))
+	S := make([]float64, nd*nd)
+	for i := uint(0); i < nd; i++ {
+		for j := uint(0); j < nd; j++ {
+			S[j*nd+i] = R[index[j]*n+index[i]]
+		}
+	}
+	return S
+}
+
+// Validate checks that a matrix is symmetric, has a unit diagonal, and is
+// positive definite. If the last property does not hold and the repair flag
+// is set, the matrix is replaced with the nearest, in the Frobenius norm,
+// correlation matrix whose eigenvalues are bounded from below by a small
+// positive constant, which is found using the alternating projections method
+// with Dykstra’s correction proposed by Higham (2002).
+func Validate(R []float64, n uint, repair bool) ([]float64, error) {
+	for i := uint(0); i < n; i++ {
+		if math.Abs(R[i*n+i]-1.0) > matrixEpsilon {
+			return nil, errors.New("the diagonal of the correlation matrix should be unit")
+		}
+		for j := i + 1; j < n; j++ {
+			if math.Abs(R[j*n+i]-R[i*n+j]) > matrixEpsilon {
+				return nil, errors.New("the correlation matrix should be symmetric")
+			}
+		}
+	}
+
+	definite, err := isDefinite(R, n)
+	if err != nil {
+		return nil, err
+	}
+	if definite {
+		return R, nil
+	}
+	if !repair {
+		return nil, errors.New("the correlation matrix should be positive definite")
+	}
+
+	return nearest(R, n)
+}
+
+func nearest(R []float64, n uint) ([]float64, error) {
+	Y := append([]float64(nil), R...)
+	X := make([]float64, n*n)
+	A := make([]float64, n*n)
+	Δ := make([]float64, n*n)
+
+	U := make([]float64, n*n)
+	Λ := make([]float64, n)
+
+	for k := 0; k < repairIterations; k++ {
+		// Projection onto the matrices with bounded eigenvalues
+		for i := range A {
+			A[i] = Y[i] - Δ[i]
+		}
+		if err := decomposition.SymmetricEigen(append([]float64(nil), A...), U, Λ, n); err != nil {
+			return nil, err
+		}
+		for i := range Λ {
+			Λ[i] = math.Max(Λ[i], repairEpsilon)
+		}
+		for i := uint(0); i < n; i++ {
+			for j := uint(0); j < n; j++ {
+				Σ := 0.0
+				for l := uint(0); l < n; l++ {
+					Σ += U[l*n+i] * Λ[l] * U[l*n+j]
+				}
+				X[j*n+i] = Σ
+			}
+		}
+		for i := range Δ {
+			Δ[i] = X[i] - A[i]
+		}
+
+		// Projection onto the matrices with unit diagonals
+		change, norm := 0.0, 0.0
+		for i := uint(0); i < n; i++ {
+			for j := uint(0); j < n; j++ {
+				value := X[j*n+i]
+				if i == j {
+					value = 1.0
+				}
+				change += (value - Y[j*n+i]) * (value - Y[j*n+i])
+				norm += value * value
+				Y[j*n+i] = value
+			}
+		}
+
+		if math.Sqrt(change) <= repairTolerance*math.Sqrt(norm) {
+			break
+		}
+	}
+
+	for i := uint(0); i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			Y[j*n+i] = 0.5 * (Y[j*n+i] + Y[i*n+j])
+			Y[i*n+j] = Y[j*n+i]
+		}
+	}
+
+	if definite, err := isDefinite(Y, n); err != nil {
+		return nil, err
+	} else if !definite {
+		return nil, errors.New("cannot find a positive definite correlation matrix " +
+			"close to the given one")
+	}
+
+	return Y, nil
+}
+
+func isDefinite(R []float64, n uint) (bool, error) {
+	U := make([]float64, n*n)
+	Λ := make([]float64, n)
+	if err := decomposition.SymmetricEigen(append([]float64(nil), R...), U, Λ, n); err != nil {
+		return false, err
+	}
+	for _, λ := range Λ {
+		if λ <= matrixEpsilon {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func loadCSV(path string) ([]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	data := []float64{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, chunk := range record {
+			value, err := strconv.ParseFloat(strings.TrimSpace(chunk), 64)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", chunk, path))
+			}
+			data = append(data, value)
+		}
+	}
+
+	return data, nil
+}
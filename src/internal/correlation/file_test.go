@@ -0,0 +1,89 @@
+package correlation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/ready-steady/linear/decomposition"
+)
+
+func TestLoad(t *testing.T) {
+	R, n, err := Load("fixtures/003.csv")
+	assert.Success(err, t)
+	assert.Equal(n, uint(3), t)
+	assert.Equal(R, []float64{
+		1.0, 0.5, 0.2,
+		0.5, 1.0, 0.3,
+		0.2, 0.3, 1.0,
+	}, t)
+
+	_, _, err = Load("fixtures/invalid.csv")
+	assert.Failure(err, t)
+}
+
+func TestSelect(t *testing.T) {
+	R := []float64{
+		1.0, 0.5, 0.2,
+		0.5, 1.0, 0.3,
+		0.2, 0.3, 1.0,
+	}
+
+	assert.Equal(Select(R, 3, []uint{0, 2}), []float64{
+		1.0, 0.2,
+		0.2, 1.0,
+	}, t)
+}
+
+func TestValidate(t *testing.T) {
+	R := []float64{
+		1.0, 0.5, 0.2,
+		0.5, 1.0, 0.3,
+		0.2, 0.3, 1.0,
+	}
+	S, err := Validate(R, 3, false)
+	assert.Success(err, t)
+	assert.Equal(S, R, t)
+
+	_, err = Validate([]float64{1.0, 0.5, 0.4, 1.0}, 2, true)
+	assert.Failure(err, t)
+
+	_, err = Validate([]float64{2.0, 0.5, 0.5, 1.0}, 2, true)
+	assert.Failure(err, t)
+
+	R = []float64{
+		1.0, 0.9, -0.9,
+		0.9, 1.0, 0.9,
+		-0.9, 0.9, 1.0,
+	}
+	_, err = Validate(R, 3, false)
+	assert.Failure(err, t)
+
+	S, err = Validate(R, 3, true)
+	assert.Success(err, t)
+
+	U := make([]float64, 3*3)
+	Λ := make([]float64, 3)
+	assert.Success(decomposition.SymmetricEigen(S, U, Λ, 3), t)
+	for i := uint(0); i < 3; i++ {
+		assert.Equal(S[i*3+i], 1.0, t)
+		assert.Equal(Λ[i] > 0.0, true, t)
+		for j := uint(0); j < 3; j++ {
+			assert.Close(S[i*3+j], S[j*3+i], 1e-15, t)
+			assert.Equal(math.Abs(S[i*3+j]) <= 1.0, true, t)
+		}
+	}
+
+	R = []float64{
+		1.0, 1.0, 0.0,
+		1.0, 1.0, 1.0,
+		0.0, 1.0, 1.0,
+	}
+	S, err = Validate(R, 3, true)
+	assert.Success(err, t)
+	assert.Close(S, []float64{
+		1.0000, 0.7607, 0.1573,
+		0.7607, 1.0000, 0.7607,
+		0.1573, 0.7607, 1.0000,
+	}, 1e-4, t)
+}
@@ -0,0 +1,53 @@
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// Kernel is a correlation function of the distance between two tasks.
+type Kernel func(float64) float64
+
+// NewKernel creates a correlation kernel. The name is either “exponential,”
+// “squared-exponential,” “matern-32,” “matern-52,” or “rational-quadratic,”
+// and it defaults to “squared-exponential.” The shape parameter is used only by
+// the rational-quadratic kernel, which tends to the squared-exponential one as
+// the shape tends to infinity.
+func NewKernel(name string, length, shape float64) (Kernel, error) {
+	if length < 0.0 {
+		return nil, errors.New("the correlation length should be nonnegative")
+	}
+	if length == 0.0 {
+		return func(float64) float64 { return 0.0 }, nil
+	}
+
+	switch name {
+	case "exponential":
+		return func(d float64) float64 {
+			return math.Exp(-d / length)
+		}, nil
+	case "", "squared-exponential":
+		return func(d float64) float64 {
+			return math.Exp(-d * d / (length * length))
+		}, nil
+	case "matern-32":
+		return func(d float64) float64 {
+			r := math.Sqrt(3.0) * d / length
+			return (1.0 + r) * math.Exp(-r)
+		}, nil
+	case "matern-52":
+		return func(d float64) float64 {
+			r := math.Sqrt(5.0) * d / length
+			return (1.0 + r + r*r/3.0) * math.Exp(-r)
+		}, nil
+	case "rational-quadratic":
+		if shape <= 0.0 {
+			return nil, errors.New("the shape of the correlation kernel should be positive")
+		}
+		return func(d float64) float64 {
+			return math.Pow(1.0+d*d/(shape*length*length), -shape)
+		}, nil
+	default:
+		return nil, errors.New("the correlation kernel is unknown")
+	}
+}
@@ -0,0 +1,39 @@
+package correlation
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestNewKernel(t *testing.T) {
+	cases := []struct {
+		name  string
+		value float64
+	}{
+		{"exponential", 4.723665527410147e-01},
+		{"", 5.697828247309230e-01},
+		{"squared-exponential", 5.697828247309230e-01},
+		{"matern-32", 6.271639525935852e-01},
+		{"matern-52", 6.756478000186596e-01},
+		{"rational-quadratic", 6.091612135633552e-01},
+	}
+
+	for _, c := range cases {
+		kernel, err := NewKernel(c.name, 2.0, 2.0)
+		assert.Success(err, t)
+		assert.Close(kernel(0.0), 1.0, 1e-15, t)
+		assert.Close(kernel(1.5), c.value, 1e-15, t)
+	}
+
+	kernel, err := NewKernel("exponential", 0.0, 0.0)
+	assert.Success(err, t)
+	assert.Equal(kernel(1.5), 0.0, t)
+
+	_, err = NewKernel("exponential", -1.0, 0.0)
+	assert.Failure(err, t)
+	_, err = NewKernel("rational-quadratic", 2.0, 0.0)
+	assert.Failure(err, t)
+	_, err = NewKernel("gaussian", 2.0, 0.0)
+	assert.Failure(err, t)
+}
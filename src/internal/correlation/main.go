@@ -6,22 +6,23 @@ import (
 	"github.com/turing-complete/system"
 )
 
-func Compute(application *system.Application, index []uint, length float64) []float64 {
+func Compute(application *system.Application, index []uint,
+	metric Metric, kernel Kernel) []float64 {
+
 	nt, nd := uint(len(application.Tasks)), uint(len(index))
 
-	distance := measure(application)
+	distance := metric(application)
 	R := make([]float64, nd*nd)
 
 	for i := uint(0); i < nd; i++ {
 		R[i*nd+i] = 1
 
-		if length == 0 {
-			continue
-		}
-
 		for j := i + 1; j < nd; j++ {
 			d := distance[index[i]*nt+index[j]]
-			R[j*nd+i] = math.Exp(-d * d / (length * length))
+			if math.IsInf(d, 1) {
+				continue
+			}
+			R[j*nd+i] = kernel(d)
 			R[i*nd+j] = R[j*nd+i]
 		}
 	}
@@ -12,20 +12,23 @@ import (
 func BenchmarkCorrelate(b *testing.B) {
 	_, application, _ := system.Load("fixtures/002_020.tgff")
 	index := index(20)
+	kernel, _ := NewKernel("squared-exponential", 2, 0)
 
 	for i := 0; i < b.N; i++ {
-		Compute(application, index, 2)
+		Compute(application, index, measure, kernel)
 	}
 }
 
 func TestCorrelateSmall(t *testing.T) {
 	_, application, _ := system.Load("fixtures/002_020.tgff")
 
-	R := Compute(application, index(20), 2)
+	kernel, _ := NewKernel("squared-exponential", 2, 0)
+
+	R := Compute(application, index(20), measure, kernel)
 	_, _, err := decomposition.CovPCA(R, 20, 0)
 	assert.Success(err, t)
 
-	R = Compute(application, index(1), 2)
+	R = Compute(application, index(1), measure, kernel)
 	assert.Equal(R, []float64{1.0}, t)
 }
 
@@ -33,11 +36,25 @@ func TestCorrelateLarge(t *testing.T) {
 	_, application, _ := system.Load("fixtures/016_160.tgff")
 
 	ε := math.Sqrt(math.Nextafter(1.0, 2.0) - 1.0)
-	R := Compute(application, index(160), 5)
+	kernel, _ := NewKernel("squared-exponential", 5, 0)
+	R := Compute(application, index(160), measure, kernel)
 	_, _, err := decomposition.CovPCA(R, 160, ε)
 	assert.Success(err, t)
 }
 
+func TestCorrelateKernels(t *testing.T) {
+	_, application, _ := system.Load("fixtures/016_160.tgff")
+
+	for _, name := range []string{"exponential", "matern-32", "matern-52", "rational-quadratic"} {
+		kernel, err := NewKernel(name, 5, 1)
+		assert.Success(err, t)
+
+		R := Compute(application, index(160), measure, kernel)
+		_, _, err = decomposition.CovPCA(R, 160, 0)
+		assert.Success(err, t)
+	}
+}
+
 func TestMeasure(t *testing.T) {
 	_, application, _ := system.Load("fixtures/002_020.tgff")
 	distance := measure(application)
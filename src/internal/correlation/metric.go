@@ -0,0 +1,178 @@
+package correlation
+
+import (
+	"errors"
+	"math"
+
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+// Metric computes the distances between all pairs of tasks.
+type Metric func(*system.Application) []float64
+
+// NewMetric creates a metric of the distance between tasks. The name is either
+// “layout,” “hop,” “undirected-hop,” “communication,” or “mapping,” and it
+// defaults to “layout.”
+//
+// The layout metric is the Euclidean distance between tasks placed on a plane
+// according to their depths in the graph and their positions within the
+// corresponding levels. The hop metrics are the lengths of the shortest paths
+// between tasks with or without respecting the direction of the arcs; tasks
+// that are not connected by a directed path are infinitely far from each
+// other. The communication metric is the undirected hop metric in which the
+// length of an arc is inversely proportional to the quantity of data that the
+// arc carries, which is given by the volume matrix, with the average arc having
+// unit length. The mapping metric is the undirected hop metric in which tasks
+// executed one after another on the same core are also adjacent, which is
+// given by the schedule.
+func NewMetric(name string, application *system.Application, schedule *time.Schedule,
+	volume []float64) (Metric, error) {
+
+	switch name {
+	case "", "layout":
+		return measure, nil
+	case "hop":
+		return func(application *system.Application) []float64 {
+			return measureHop(application, true)
+		}, nil
+	case "undirected-hop":
+		return func(application *system.Application) []float64 {
+			return measureHop(application, false)
+		}, nil
+	case "communication":
+		if volume == nil {
+			return nil, errors.New("the communication metric requires communication quantities")
+		}
+		if _, count := averageVolume(application, volume); count == 0 {
+			return nil, errors.New("the communication metric requires at least one arc " +
+				"with a positive quantity of data")
+		}
+		return func(application *system.Application) []float64 {
+			return measureCommunication(application, volume)
+		}, nil
+	case "mapping":
+		if schedule == nil {
+			return nil, errors.New("the mapping metric requires a schedule")
+		}
+		return func(application *system.Application) []float64 {
+			return measureMapping(application, schedule)
+		}, nil
+	default:
+		return nil, errors.New("the correlation metric is unknown")
+	}
+}
+
+func measureCommunication(application *system.Application, volume []float64) []float64 {
+	nt := uint(len(application.Tasks))
+
+	average, _ := averageVolume(application, volume)
+
+	distance := connect(nt)
+	for i := uint(0); i < nt; i++ {
+		for _, j := range application.Tasks[i].Children {
+			if v := volume[i*nt+j]; v > 0.0 {
+				distance[i*nt+j] = average / v
+				distance[j*nt+i] = average / v
+			}
+		}
+	}
+
+	return traverse(distance, nt)
+}
+
+func measureHop(application *system.Application, directed bool) []float64 {
+	nt := uint(len(application.Tasks))
+
+	distance := connect(nt)
+	for i := uint(0); i < nt; i++ {
+		for _, j := range application.Tasks[i].Children {
+			distance[i*nt+j] = 1.0
+			if !directed {
+				distance[j*nt+i] = 1.0
+			}
+		}
+	}
+
+	distance = traverse(distance, nt)
+
+	if directed {
+		for i := uint(0); i < nt; i++ {
+			for j := i + 1; j < nt; j++ {
+				d := math.Min(distance[i*nt+j], distance[j*nt+i])
+				distance[i*nt+j], distance[j*nt+i] = d, d
+			}
+		}
+	}
+
+	return distance
+}
+
+func measureMapping(application *system.Application, schedule *time.Schedule) []float64 {
+	nt := uint(len(application.Tasks))
+
+	distance := connect(nt)
+	for i := uint(0); i < nt; i++ {
+		for _, j := range application.Tasks[i].Children {
+			distance[i*nt+j] = 1.0
+			distance[j*nt+i] = 1.0
+		}
+	}
+
+	last := make(map[uint]uint)
+	for _, j := range schedule.Order {
+		if i, ok := last[schedule.Mapping[j]]; ok {
+			distance[i*nt+j] = 1.0
+			distance[j*nt+i] = 1.0
+		}
+		last[schedule.Mapping[j]] = j
+	}
+
+	return traverse(distance, nt)
+}
+
+func connect(nt uint) []float64 {
+	distance := make([]float64, nt*nt)
+	for i := uint(0); i < nt; i++ {
+		for j := uint(0); j < nt; j++ {
+			if i != j {
+				distance[i*nt+j] = math.Inf(1)
+			}
+		}
+	}
+	return distance
+}
+
+func traverse(distance []float64, nt uint) []float64 {
+	for k := uint(0); k < nt; k++ {
+		for i := uint(0); i < nt; i++ {
+			for j := uint(0); j < nt; j++ {
+				if d := distance[i*nt+k] + distance[k*nt+j]; d < distance[i*nt+j] {
+					distance[i*nt+j] = d
+				}
+			}
+		}
+	}
+	return distance
+}
+
+// averageVolume computes the average quantity of data carried by the arcs with
+// positive quantities and counts such arcs.
+func averageVolume(application *system.Application, volume []float64) (float64, uint) {
+	nt := uint(len(application.Tasks))
+
+	sum, count := 0.0, uint(0)
+	for i := uint(0); i < nt; i++ {
+		for _, j := range application.Tasks[i].Children {
+			if v := volume[i*nt+j]; v > 0.0 {
+				sum += v
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0.0, 0
+	}
+
+	return sum / float64(count), count
+}
@@ -0,0 +1,112 @@
+package correlation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+func TestMeasureHop(t *testing.T) {
+	_, application, _ := system.Load("fixtures/002_020.tgff")
+
+	directed := measureHop(application, true)
+	undirected := measureHop(application, false)
+
+	cases := []struct {
+		i uint
+		j uint
+		d float64
+		u float64
+	}{
+		{0, 0, 0.0, 0.0},
+		{0, 1, 1.0, 1.0},
+		{1, 0, 1.0, 1.0},
+		{0, 2, 2.0, 2.0},
+		{0, 18, 5.0, 5.0},
+		{5, 19, 2.0, 2.0},
+		{3, 4, math.Inf(1), 2.0},
+		{6, 7, math.Inf(1), 2.0},
+	}
+
+	for _, c := range cases {
+		assert.Equal(directed[20*c.i+c.j], c.d, t)
+		assert.Equal(undirected[20*c.i+c.j], c.u, t)
+	}
+}
+
+func TestMeasureCommunication(t *testing.T) {
+	_, application, _ := system.Load("fixtures/002_020.tgff")
+
+	volume := make([]float64, 20*20)
+	for i, task := range application.Tasks {
+		for _, j := range task.Children {
+			volume[i*20+int(j)] = 2.0
+			volume[int(j)*20+i] = 2.0
+		}
+	}
+	volume[0*20+1], volume[1*20+0] = 8.0, 8.0
+
+	average := (22.0*2.0 + 8.0) / 23.0
+	distance := measureCommunication(application, volume)
+
+	assert.Close(distance[0*20+1], average/8.0, 1e-15, t)
+	assert.Close(distance[0*20+2], average/8.0+average/2.0, 1e-15, t)
+	assert.Close(distance[6*20+7], 2.0*average/2.0, 1e-15, t)
+}
+
+func TestMeasureMapping(t *testing.T) {
+	_, application, _ := system.Load("fixtures/002_020.tgff")
+
+	schedule := &time.Schedule{
+		Mapping: make([]uint, 20),
+		Order:   make([]uint, 20),
+	}
+	for i := uint(0); i < 20; i++ {
+		schedule.Mapping[i] = i % 2
+		schedule.Order[i] = i
+	}
+
+	distance := measureMapping(application, schedule)
+
+	assert.Equal(distance[6*20+7], 2.0, t)
+	assert.Equal(distance[6*20+8], 1.0, t)
+	assert.Equal(distance[0*20+18], 3.0, t)
+	assert.Equal(distance[3*20+4], 2.0, t)
+}
+
+func TestNewMetric(t *testing.T) {
+	application := &system.Application{Tasks: []system.Task{
+		{ID: 0, Children: []uint{1}},
+		{ID: 1, Parents: []uint{0}},
+		{ID: 2},
+	}}
+
+	for _, name := range []string{"", "layout", "hop", "undirected-hop"} {
+		_, err := NewMetric(name, application, nil, nil)
+		assert.Success(err, t)
+	}
+
+	_, err := NewMetric("communication", application, nil, nil)
+	assert.Failure(err, t)
+	_, err = NewMetric("communication", application, nil, make([]float64, 3*3))
+	assert.Failure(err, t)
+	_, err = NewMetric("communication", application, nil, []float64{
+		0.0, 0.0, 1.0,
+		0.0, 0.0, 0.0,
+		1.0, 0.0, 0.0,
+	})
+	assert.Failure(err, t)
+	_, err = NewMetric("communication", application, nil, []float64{
+		0.0, 1.0, 0.0,
+		1.0, 0.0, 0.0,
+		0.0, 0.0, 0.0,
+	})
+	assert.Success(err, t)
+	_, err = NewMetric("mapping", application, nil, nil)
+	assert.Failure(err, t)
+	_, err = NewMetric("euclidean", application, nil, nil)
+	assert.Failure(err, t)
+}
@@ -27,3 +27,30 @@ func Open(path string) (*hdf5.File, error) {
 
 	return hdf5.Open(path)
 }
+
+// Replace writes a file by creating a temporary file next to it, passing it to
+// write, and renaming it into place. An existing file at the path is left
+// intact unless the whole write succeeds.
+func Replace(path string, write func(*hdf5.File) error) error {
+	if len(path) == 0 {
+		return errors.New("expected a filename")
+	}
+
+	temporary := path + ".tmp"
+
+	file, err := hdf5.Create(temporary)
+	if err != nil {
+		return err
+	}
+	if err = write(file); err != nil {
+		file.Close()
+		os.Remove(temporary)
+		return err
+	}
+	if err = file.Close(); err != nil {
+		os.Remove(temporary)
+		return err
+	}
+
+	return os.Rename(temporary, path)
+}
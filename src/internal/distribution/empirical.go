@@ -0,0 +1,205 @@
+package distribution
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	empiricalKnots = 101
+)
+
+var (
+	empiricalPattern = regexp.MustCompile(`(?i)^\s*empirical\s*\((.*)\)\s*$`)
+)
+
+// ParseTasks creates the distributions of a set of tasks given a textual
+// description. In addition to the forms accepted by Parse, it accepts
+// Empirical(path) and Empirical(path, bandwidth > 0), which refer to a CSV or
+// JSON file with samples of the execution times of the tasks. In the CSV case,
+// the samples of a task are in the column whose number is equal to the task’s
+// index; in the JSON case, the file contains an array of such columns. The
+// samples are mapped to the unit interval using the bounds of the tasks, and
+// the density is estimated using a Gaussian kernel. The samples outside the
+// bounds are considered outliers and dropped with a warning. If the bandwidth
+// is not given, it is computed using Silverman’s rule of thumb.
+func ParseTasks(line string, tasks []uint, lower, upper []float64) ([]Continuous, error) {
+	nt := uint(len(tasks))
+	distributions := make([]Continuous, nt)
+
+	path, bandwidth, ok := parseEmpirical(line)
+	if !ok {
+		distribution, err := Parse(line)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint(0); i < nt; i++ {
+			distributions[i] = distribution
+		}
+		return distributions, nil
+	}
+
+	data, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tid := range tasks {
+		if tid >= uint(len(data)) || len(data[tid]) == 0 {
+			return nil, errors.New(fmt.Sprintf("the file “%s” has no samples of task %d", path, tid))
+		}
+		samples := make([]float64, 0, len(data[tid]))
+		for _, s := range data[tid] {
+			if s >= lower[i] && s <= upper[i] && lower[i] < upper[i] {
+				samples = append(samples, (s-lower[i])/(upper[i]-lower[i]))
+			}
+		}
+		if len(samples) == 0 {
+			return nil, errors.New(fmt.Sprintf("the file “%s” has no samples of task %d "+
+				"within [%g, %g]", path, tid, lower[i], upper[i]))
+		}
+		if outliers := len(data[tid]) - len(samples); outliers > 0 {
+			log.Printf("Dropped %d of %d samples of task %d outside [%g, %g].\n",
+				outliers, len(data[tid]), tid, lower[i], upper[i])
+		}
+		distributions[i] = newEmpirical(samples, bandwidth)
+	}
+
+	return distributions, nil
+}
+
+func newEmpirical(samples []float64, bandwidth float64) *piecewise {
+	ns := float64(len(samples))
+
+	if bandwidth == 0.0 {
+		μ, σ := 0.0, 0.0
+		for _, s := range samples {
+			μ += s
+		}
+		μ /= ns
+		for _, s := range samples {
+			σ += (s - μ) * (s - μ)
+		}
+		σ = math.Sqrt(σ / ns)
+		bandwidth = 1.06 * σ * math.Pow(ns, -0.2)
+	}
+	if bandwidth == 0.0 {
+		bandwidth = 1.0 / (empiricalKnots - 1)
+	}
+
+	kernel := func(x float64) float64 {
+		x /= bandwidth
+		return math.Exp(-0.5*x*x) / math.Sqrt(2.0*math.Pi)
+	}
+
+	x := make([]float64, empiricalKnots)
+	w := make([]float64, empiricalKnots)
+	for i := range x {
+		x[i] = float64(i) / (empiricalKnots - 1)
+		for _, s := range samples {
+			// The samples are reflected with respect to the boundaries.
+			w[i] += kernel(x[i]-s) + kernel(x[i]+s) + kernel(x[i]-2.0+s)
+		}
+	}
+
+	return newPiecewise(x, w)
+}
+
+func parseEmpirical(line string) (string, float64, bool) {
+	match := empiricalPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", 0.0, false
+	}
+
+	chunks := strings.Split(match[1], ",")
+	if len(chunks) > 2 {
+		return "", 0.0, false
+	}
+
+	path := strings.Trim(trim(chunks[0]), `"'`)
+	if len(path) == 0 {
+		return "", 0.0, false
+	}
+
+	bandwidth := 0.0
+	if len(chunks) == 2 {
+		value, err := strconv.ParseFloat(trim(chunks[1]), 64)
+		if err != nil || value <= 0.0 {
+			return "", 0.0, false
+		}
+		bandwidth = value
+	}
+
+	return path, bandwidth, true
+}
+
+func load(path string) ([][]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		data := [][]float64{}
+		if err := json.NewDecoder(file).Decode(&data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	data := [][]float64{}
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make([]float64, len(record))
+		present := make([]bool, len(record))
+		header := false
+		for i, chunk := range record {
+			if len(chunk) == 0 {
+				continue
+			}
+			values[i], err = strconv.ParseFloat(chunk, 64)
+			if err != nil {
+				if row == 0 {
+					header = true
+					break
+				}
+				return nil, errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", chunk, path))
+			}
+			present[i] = true
+		}
+		if header {
+			continue
+		}
+		for len(data) < len(record) {
+			data = append(data, nil)
+		}
+		for i := range record {
+			if present[i] {
+				data[i] = append(data[i], values[i])
+			}
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,48 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestParseTasks(t *testing.T) {
+	lower := []float64{0.01, 0.02}
+	upper := []float64{0.012, 0.04}
+
+	for _, path := range []string{"fixtures/traces.csv", "fixtures/traces.json"} {
+		distributions, err := ParseTasks("Empirical(\""+path+"\")", []uint{0, 1}, lower, upper)
+		assert.Success(err, t)
+		assert.Equal(len(distributions), 2, t)
+
+		for _, distribution := range distributions {
+			assert.Equal(distribution.Cumulate(0.0), 0.0, t)
+			assert.Equal(distribution.Cumulate(1.0), 1.0, t)
+			for i := 1; i < 10; i++ {
+				x := float64(i) / 10.0
+				assert.Close(distribution.Invert(distribution.Cumulate(x)), x, 1e-12, t)
+			}
+		}
+
+		_, err = ParseTasks("Empirical("+path+", 0.1)", []uint{1}, lower[1:], upper[1:])
+		assert.Success(err, t)
+
+		distributions, err = ParseTasks("Empirical("+path+")", []uint{1},
+			[]float64{0.02}, []float64{0.03})
+		assert.Success(err, t)
+		assert.Equal(distributions[0].Cumulate(1.0), 1.0, t)
+
+		_, err = ParseTasks("Empirical("+path+")", []uint{2}, lower[1:], upper[1:])
+		assert.Failure(err, t)
+
+		_, err = ParseTasks("Empirical("+path+")", []uint{1}, lower[:1], upper[:1])
+		assert.Failure(err, t)
+	}
+
+	distributions, err := ParseTasks("Uniform()", []uint{0, 1}, lower, upper)
+	assert.Success(err, t)
+	assert.Equal(len(distributions), 2, t)
+
+	_, err = Parse("Empirical(fixtures/traces.csv)")
+	assert.Failure(err, t)
+}
@@ -0,0 +1,47 @@
+package distribution
+
+import (
+	"math"
+
+	"github.com/ready-steady/probability/distribution"
+)
+
+type gaussian struct {
+	base Continuous
+
+	a float64
+	b float64
+}
+
+func newGaussian(μ, σ float64) *gaussian {
+	base := distribution.NewGaussian(μ, σ)
+	return &gaussian{
+		base: base,
+
+		a: base.Cumulate(0.0),
+		b: base.Cumulate(1.0),
+	}
+}
+
+// measure computes the probability mass of a Gaussian distribution on the unit
+// interval.
+func measure(μ, σ float64) float64 {
+	return 0.5 * (math.Erf((1.0-μ)/(σ*math.Sqrt2)) - math.Erf(-μ/(σ*math.Sqrt2)))
+}
+
+func (self *gaussian) Cumulate(x float64) float64 {
+	x = math.Min(math.Max(x, 0.0), 1.0)
+	return (self.base.Cumulate(x) - self.a) / (self.b - self.a)
+}
+
+func (self *gaussian) Invert(p float64) float64 {
+	x := self.base.Invert(self.a + p*(self.b-self.a))
+	return math.Min(math.Max(x, 0.0), 1.0)
+}
+
+func (self *gaussian) Weigh(x float64) float64 {
+	if x < 0.0 || x > 1.0 {
+		return 0.0
+	}
+	return self.base.Weigh(x) / (self.b - self.a)
+}
@@ -0,0 +1,32 @@
+package distribution
+
+import (
+	"math"
+)
+
+type kumaraswamy struct {
+	a float64
+	b float64
+}
+
+func newKumaraswamy(a, b float64) *kumaraswamy {
+	return &kumaraswamy{a: a, b: b}
+}
+
+func (self *kumaraswamy) Cumulate(x float64) float64 {
+	x = math.Min(math.Max(x, 0.0), 1.0)
+	return 1.0 - math.Pow(1.0-math.Pow(x, self.a), self.b)
+}
+
+func (self *kumaraswamy) Invert(p float64) float64 {
+	p = math.Min(math.Max(p, 0.0), 1.0)
+	return math.Pow(1.0-math.Pow(1.0-p, 1.0/self.b), 1.0/self.a)
+}
+
+func (self *kumaraswamy) Weigh(x float64) float64 {
+	if x < 0.0 || x > 1.0 {
+		return 0.0
+	}
+	a, b := self.a, self.b
+	return a * b * math.Pow(x, a-1.0) * math.Pow(1.0-math.Pow(x, a), b-1.0)
+}
@@ -2,6 +2,7 @@ package distribution
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -9,24 +10,86 @@ import (
 	"github.com/ready-steady/probability/distribution"
 )
 
+// Continuous is a continuous distribution.
+type Continuous interface {
+	// Cumulate evaluates the cumulative distribution function.
+	Cumulate(float64) float64
+	// Invert evaluates the inverse of the cumulative distribution function.
+	Invert(float64) float64
+	// Weigh evaluates the probability density function.
+	Weigh(float64) float64
+}
+
 type family uint
 
 const (
 	unknownFamily family = iota
 	betaFamily
+	gaussianFamily
+	kumaraswamyFamily
+	pertFamily
+	piecewiseFamily
+	triangularFamily
 	uniformFamily
 )
 
-func Parse(line string) (distribution.Continuous, error) {
+var forms = []string{
+	"Beta(α > 0, β > 0)",
+	"Empirical(path)",
+	"Empirical(path, bandwidth > 0)",
+	"Gaussian(μ, σ > 0)",
+	"Kumaraswamy(a > 0, b > 0)",
+	"PERT(0 ≤ m ≤ 1)",
+	"PERT(0 ≤ m ≤ 1, λ > 0)",
+	"Piecewise(w0 ≥ 0, …, wn ≥ 0)",
+	"Triangular(0 ≤ c ≤ 1)",
+	"Uniform()",
+}
+
+// Parse creates a distribution on the unit interval given its textual
+// description. The Gaussian distribution is truncated to the interval, and the
+// weights of the piecewise-linear distribution are the values of the density,
+// up to normalization, at equidistant knots.
+func Parse(line string) (Continuous, error) {
+	if _, _, ok := parseEmpirical(line); ok {
+		return nil, errors.New(fmt.Sprintf("the marginal distribution “%s” is specific "+
+			"to tasks", trim(line)))
+	}
+
 	family, params := parse(line)
 
 	switch family {
 	case betaFamily:
 		return distribution.NewBeta(params[0], params[1], 0.0, 1.0), nil
+	case gaussianFamily:
+		if measure(params[0], params[1]) <= 0.0 {
+			return nil, errors.New(fmt.Sprintf("the marginal distribution “%s” has no "+
+				"probability mass on the unit interval", trim(line)))
+		}
+		return newGaussian(params[0], params[1]), nil
+	case kumaraswamyFamily:
+		return newKumaraswamy(params[0], params[1]), nil
+	case pertFamily:
+		λ := 4.0
+		if len(params) > 1 {
+			λ = params[1]
+		}
+		α, β := 1.0+λ*params[0], 1.0+λ*(1.0-params[0])
+		return distribution.NewBeta(α, β, 0.0, 1.0), nil
+	case piecewiseFamily:
+		nk := uint(len(params))
+		knots := make([]float64, nk)
+		for i := uint(0); i < nk; i++ {
+			knots[i] = float64(i) / float64(nk-1)
+		}
+		return newPiecewise(knots, params), nil
+	case triangularFamily:
+		return newTriangular(params[0]), nil
 	case uniformFamily:
 		return distribution.NewUniform(0.0, 1.0), nil
 	default:
-		return nil, errors.New("the marginal distribution is unknown")
+		return nil, errors.New(fmt.Sprintf("the marginal distribution “%s” is unknown "+
+			"or invalid; the accepted forms are %s", trim(line), strings.Join(forms, ", ")))
 	}
 }
 
@@ -57,6 +120,40 @@ func parse(line string) (family, []float64) {
 		if len(params) == 2 && params[0] > 0.0 && params[1] > 0.0 {
 			return betaFamily, params
 		}
+	case "gaussian":
+		if len(params) == 2 && params[1] > 0.0 {
+			return gaussianFamily, params
+		}
+	case "kumaraswamy":
+		if len(params) == 2 && params[0] > 0.0 && params[1] > 0.0 {
+			return kumaraswamyFamily, params
+		}
+	case "pert":
+		if (len(params) == 1 || len(params) == 2 && params[1] > 0.0) &&
+			params[0] >= 0.0 && params[0] <= 1.0 {
+
+			return pertFamily, params
+		}
+	case "piecewise":
+		if len(params) < 2 {
+			break
+		}
+		positive := false
+		for i := range params {
+			if params[i] < 0.0 {
+				return unknownFamily, nil
+			}
+			if params[i] > 0.0 {
+				positive = true
+			}
+		}
+		if positive {
+			return piecewiseFamily, params
+		}
+	case "triangular":
+		if len(params) == 1 && params[0] >= 0.0 && params[0] <= 1.0 {
+			return triangularFamily, params
+		}
 	case "uniform":
 		if len(params) == 0 {
 			return uniformFamily, params
@@ -23,6 +23,27 @@ func TestParse(t *testing.T) {
 		{"beta(1, 0)", false},
 		{"uniform()", true},
 		{"uniform( )", true},
+		{"uniform(1)", false},
+		{"Gaussian(0.5, 0.1)", true},
+		{"gaussian(-1, 2)", true},
+		{"Gaussian(0.5, 0)", false},
+		{"Gaussian(0.5)", false},
+		{"Gaussian(10, 0.1)", false},
+		{"Gaussian(-10, 0.1)", false},
+		{"Kumaraswamy(2, 5)", true},
+		{"Kumaraswamy(0, 5)", false},
+		{"PERT(0.3)", true},
+		{"pert(0.3, 2)", true},
+		{"PERT(1.3)", false},
+		{"PERT(0.3, 0)", false},
+		{"Piecewise(0, 1, 2, 1)", true},
+		{"Piecewise(0, 0)", false},
+		{"Piecewise(1)", false},
+		{"Piecewise(1, -1, 1)", false},
+		{"Triangular(0)", true},
+		{"triangular(0.25)", true},
+		{"Triangular(1)", true},
+		{"Triangular(1.5)", false},
 	}
 
 	for _, c := range cases {
@@ -33,3 +54,24 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestParseInvert(t *testing.T) {
+	lines := []string{
+		"Kumaraswamy(2, 5)",
+		"Piecewise(0, 1, 2, 1)",
+		"Triangular(0)",
+		"Triangular(0.25)",
+		"Triangular(1)",
+	}
+
+	for _, line := range lines {
+		distribution, err := Parse(line)
+		assert.Success(err, t)
+		for i := 0; i <= 10; i++ {
+			x := float64(i) / 10.0
+			assert.Close(distribution.Invert(distribution.Cumulate(x)), x, 1e-12, t)
+		}
+		assert.Equal(distribution.Invert(-0.5), distribution.Invert(0.0), t)
+		assert.Equal(distribution.Invert(1.5), distribution.Invert(1.0), t)
+	}
+}
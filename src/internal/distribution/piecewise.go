@@ -0,0 +1,84 @@
+package distribution
+
+import (
+	"math"
+	"sort"
+)
+
+// piecewise is a distribution whose density is piecewise linear. The density
+// is given by its values at a set of knots, and it is zero outside the knots.
+type piecewise struct {
+	x []float64 // knots
+	f []float64 // density
+	F []float64 // distribution
+}
+
+func newPiecewise(x, w []float64) *piecewise {
+	nk := uint(len(x))
+
+	f := make([]float64, nk)
+	F := make([]float64, nk)
+
+	for i := uint(1); i < nk; i++ {
+		F[i] = F[i-1] + 0.5*(w[i-1]+w[i])*(x[i]-x[i-1])
+	}
+	area := F[nk-1]
+	for i := uint(0); i < nk; i++ {
+		f[i] = w[i] / area
+		F[i] /= area
+	}
+	F[nk-1] = 1.0
+
+	return &piecewise{x: x, f: f, F: F}
+}
+
+func (self *piecewise) Cumulate(x float64) float64 {
+	nk := len(self.x)
+	switch {
+	case x <= self.x[0]:
+		return 0.0
+	case x >= self.x[nk-1]:
+		return 1.0
+	}
+
+	i := sort.SearchFloat64s(self.x, x) - 1
+	h, t := self.x[i+1]-self.x[i], x-self.x[i]
+	s := (self.f[i+1] - self.f[i]) / h
+
+	return math.Min(self.F[i]+self.f[i]*t+0.5*s*t*t, 1.0)
+}
+
+func (self *piecewise) Invert(p float64) float64 {
+	nk := len(self.x)
+	switch {
+	case p <= 0.0:
+		return self.x[0]
+	case p >= 1.0:
+		return self.x[nk-1]
+	}
+
+	i := sort.SearchFloat64s(self.F, p) - 1
+	h, q := self.x[i+1]-self.x[i], p-self.F[i]
+	s := (self.f[i+1] - self.f[i]) / h
+
+	// Solve 0.5 * s * t^2 + f * t = q for t ∈ [0, h].
+	t := 2.0 * q / (self.f[i] + math.Sqrt(math.Max(self.f[i]*self.f[i]+2.0*s*q, 0.0)))
+
+	return self.x[i] + math.Min(math.Max(t, 0.0), h)
+}
+
+func (self *piecewise) Weigh(x float64) float64 {
+	nk := len(self.x)
+	if x < self.x[0] || x > self.x[nk-1] {
+		return 0.0
+	}
+
+	i := sort.SearchFloat64s(self.x, x)
+	if i == 0 {
+		return self.f[0]
+	}
+	i--
+	h, t := self.x[i+1]-self.x[i], x-self.x[i]
+
+	return self.f[i] + (self.f[i+1]-self.f[i])*t/h
+}
@@ -0,0 +1,23 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestPiecewise(t *testing.T) {
+	distribution := newPiecewise([]float64{0.0, 0.5, 1.0}, []float64{0.0, 2.0, 1.0})
+
+	x := []float64{-0.5, 0.0, 0.25, 0.5, 0.75, 1.0, 1.5}
+	f := []float64{0.0, 0.0, 0.8, 1.6, 1.2, 0.8, 0.0}
+	F := []float64{0.0, 0.0, 0.1, 0.4, 0.75, 1.0, 1.0}
+
+	for i := range x {
+		assert.Close(distribution.Weigh(x[i]), f[i], 1e-15, t)
+		assert.Close(distribution.Cumulate(x[i]), F[i], 1e-15, t)
+	}
+	for i := 1; i < len(x)-1; i++ {
+		assert.Close(distribution.Invert(F[i]), x[i], 1e-15, t)
+	}
+}
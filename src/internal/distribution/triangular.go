@@ -0,0 +1,50 @@
+package distribution
+
+import (
+	"math"
+)
+
+type triangular struct {
+	c float64
+}
+
+func newTriangular(c float64) *triangular {
+	return &triangular{c: c}
+}
+
+func (self *triangular) Cumulate(x float64) float64 {
+	c := self.c
+	switch {
+	case x <= 0.0:
+		return 0.0
+	case x >= 1.0:
+		return 1.0
+	case x < c:
+		return x * x / c
+	default:
+		return 1.0 - (1.0-x)*(1.0-x)/(1.0-c)
+	}
+}
+
+func (self *triangular) Invert(p float64) float64 {
+	c := self.c
+	p = math.Min(math.Max(p, 0.0), 1.0)
+	if p < c {
+		return math.Sqrt(p * c)
+	}
+	return 1.0 - math.Sqrt((1.0-p)*(1.0-c))
+}
+
+func (self *triangular) Weigh(x float64) float64 {
+	c := self.c
+	switch {
+	case x < 0.0 || x > 1.0:
+		return 0.0
+	case x < c:
+		return 2.0 * x / c
+	case c == 1.0:
+		return 2.0
+	default:
+		return 2.0 * (1.0 - x) / (1.0 - c)
+	}
+}
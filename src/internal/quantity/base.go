@@ -39,3 +39,14 @@ func (self *base) Dimensions() (uint, uint) {
 func (self *base) String() string {
 	return fmt.Sprintf(`{inputs:%d outputs:%d}`, self.ni, self.no)
 }
+
+// backward maps a node to the execution times of tasks and, if present, to the
+// multipliers of the dynamic power of tasks and of the static power of cores.
+func (self *base) backward(node []float64) ([]float64, []float64, []float64) {
+	ω := self.Backward(node)
+	nt := uint(self.system.Application.Len())
+	if uint(len(ω)) == nt {
+		return ω, nil, nil
+	}
+	return ω[:nt], ω[nt : 2*nt], ω[2*nt:]
+}
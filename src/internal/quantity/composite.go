@@ -0,0 +1,92 @@
+package quantity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/support"
+	"github.com/turing-complete/laboratory/src/internal/system"
+	"github.com/turing-complete/laboratory/src/internal/uncertainty"
+)
+
+// composite is a set of metrics evaluated using one schedule and one power and
+// temperature profile per node.
+type composite struct {
+	base
+
+	metrics []metric
+	thermal bool
+}
+
+type metric uint
+
+const (
+	delayMetric metric = iota
+	energyMetric
+	temperatureMetric
+	energyDelayMetric
+)
+
+func newComposite(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*composite, error) {
+
+	if len(config.Names) == 0 {
+		return nil, errors.New("the composite quantity should have at least one metric")
+	}
+
+	metrics, thermal := make([]metric, len(config.Names)), false
+	for i, name := range config.Names {
+		switch name {
+		case "end-to-end-delay":
+			metrics[i] = delayMetric
+		case "total-energy":
+			metrics[i] = energyMetric
+			thermal = true
+		case "maximum-temperature":
+			metrics[i] = temperatureMetric
+			thermal = true
+		case "energy-delay-product":
+			metrics[i] = energyDelayMetric
+			thermal = true
+		default:
+			return nil, errors.New(fmt.Sprintf("the metric “%s” is unknown", name))
+		}
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, uint(len(metrics)))
+	if err != nil {
+		return nil, err
+	}
+	return &composite{base: base, metrics: metrics, thermal: thermal}, nil
+}
+
+func (self *composite) Compute(node, value []float64) {
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+
+	delay, energy, temperature := schedule.Span, 0.0, 0.0
+	if self.thermal {
+		P := self.system.ComputeDynamicPower(schedule, dynamic)
+		Q := self.system.ComputeTemperatureUpdatePower(schedule, P, static)
+		energy = support.Sum(P) * self.system.TimeStep()
+		for _, q := range Q {
+			temperature = math.Max(temperature, q)
+		}
+	}
+
+	for i, metric := range self.metrics {
+		switch metric {
+		case delayMetric:
+			value[i] = delay
+		case energyMetric:
+			value[i] = energy
+		case temperatureMetric:
+			value[i] = temperature
+		case energyDelayMetric:
+			value[i] = energy * delay
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package quantity
 
 import (
+	"errors"
+	"fmt"
+	"math"
+
 	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/support"
 	"github.com/turing-complete/laboratory/src/internal/system"
 	"github.com/turing-complete/laboratory/src/internal/uncertainty"
 )
@@ -22,5 +27,111 @@ func newDelay(system *system.System, uncertainty uncertainty.Uncertainty,
 }
 
 func (self *delay) Compute(node []float64, value []float64) {
-	value[0] = self.system.ComputeSchedule(self.Backward(node)).Span
+	duration, _, _ := self.backward(node)
+	value[0] = self.system.ComputeSchedule(duration).Span
+}
+
+// finish is the finish times of a set of tasks.
+type finish struct {
+	base
+
+	tasks []uint
+}
+
+// slack is the differences between the deadlines and finish times of a set of
+// tasks. A negative value corresponds to a deadline miss.
+type slack struct {
+	base
+
+	tasks     []uint
+	deadlines []float64
+}
+
+func newFinish(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*finish, error) {
+
+	nt := uint(system.Application.Len())
+
+	var tasks []uint
+	if len(config.Tasks) == 0 {
+		tasks = make([]uint, nt)
+		for i := range tasks {
+			tasks[i] = uint(i)
+		}
+	} else {
+		var err error
+		if tasks, err = support.ParseNaturalIndex(config.Tasks, 0, nt-1); err != nil {
+			return nil, err
+		}
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, uint(len(tasks)))
+	if err != nil {
+		return nil, err
+	}
+	return &finish{base: base, tasks: tasks}, nil
+}
+
+func newSlack(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*slack, error) {
+
+	nt := uint(system.Application.Len())
+
+	scale := config.DeadlineScale
+	if scale == 0.0 {
+		scale = 1.0
+	} else if scale < 0.0 {
+		return nil, errors.New("the scale of deadlines should be positive")
+	}
+
+	all := system.Deadlines()
+
+	var tasks []uint
+	if len(config.Tasks) == 0 {
+		for i := uint(0); i < nt; i++ {
+			if !math.IsInf(all[i], 1) {
+				tasks = append(tasks, i)
+			}
+		}
+		if len(tasks) == 0 {
+			return nil, errors.New("there are no tasks with deadlines")
+		}
+	} else {
+		var err error
+		if tasks, err = support.ParseNaturalIndex(config.Tasks, 0, nt-1); err != nil {
+			return nil, err
+		}
+	}
+
+	deadlines := make([]float64, len(tasks))
+	for i, j := range tasks {
+		if math.IsInf(all[j], 1) {
+			return nil, errors.New(fmt.Sprintf("the task %d has no deadline", j))
+		}
+		deadlines[i] = scale * all[j]
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, uint(len(tasks)))
+	if err != nil {
+		return nil, err
+	}
+	return &slack{base: base, tasks: tasks, deadlines: deadlines}, nil
+}
+
+func (self *finish) Compute(node []float64, value []float64) {
+	duration, _, _ := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	for i, j := range self.tasks {
+		value[i] = schedule.Finish[j]
+	}
+}
+
+func (self *slack) Compute(node []float64, value []float64) {
+	duration, _, _ := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	for i, j := range self.tasks {
+		value[i] = self.deadlines[i] - schedule.Finish[j]
+	}
 }
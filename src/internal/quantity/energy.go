@@ -23,7 +23,9 @@ func newEnergy(system *system.System, uncertainty uncertainty.Uncertainty,
 }
 
 func (self *energy) Compute(node, value []float64) {
-	P := self.system.ComputeDynamicPower(self.system.ComputeSchedule(self.Backward(node)))
-	self.system.ComputeTemperatureUpdatePower(P)
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	P := self.system.ComputeDynamicPower(schedule, dynamic)
+	self.system.ComputeTemperatureUpdatePower(schedule, P, static)
 	value[0] = support.Sum(P) * self.system.TimeStep()
 }
@@ -32,12 +32,24 @@ func New(system *system.System, uncertainty uncertainty.Uncertainty,
 	config *config.Quantity) (Quantity, error) {
 
 	switch config.Name {
+	case "composite":
+		return newComposite(system, uncertainty, config)
 	case "end-to-end-delay":
 		return newDelay(system, uncertainty, config)
+	case "finish-time":
+		return newFinish(system, uncertainty, config)
+	case "deadline-slack":
+		return newSlack(system, uncertainty, config)
+	case "peak-power", "core-peak-power", "average-power", "power-density-gradient":
+		return newPower(system, uncertainty, config)
 	case "total-energy":
 		return newEnergy(system, uncertainty, config)
 	case "maximum-temperature":
 		return newTemperature(system, uncertainty, config)
+	case "core-temperature":
+		return newCoreTemperature(system, uncertainty, config)
+	case "mean-time-to-failure":
+		return newReliability(system, uncertainty, config)
 	default:
 		return nil, errors.New("the quantity is unknown")
 	}
@@ -0,0 +1,111 @@
+package quantity
+
+import (
+	"errors"
+	"math"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/support"
+	"github.com/turing-complete/laboratory/src/internal/system"
+	"github.com/turing-complete/laboratory/src/internal/uncertainty"
+)
+
+// power is a characteristic of the total, that is, dynamic and static, power
+// profile. The power-density gradient is the difference between the power
+// densities of two neighboring cores divided by the distance between their
+// centers.
+type power struct {
+	base
+
+	name string
+
+	area       []float64
+	neighbors  [][2]uint
+	separation []float64
+}
+
+func newPower(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*power, error) {
+
+	nc := uint(system.Platform.Len())
+
+	no := uint(1)
+	if config.Name == "core-peak-power" {
+		no = nc
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, no)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := &power{base: base, name: config.Name}
+	if config.Name != "power-density-gradient" {
+		return quantity, nil
+	}
+
+	floorplan, err := system.Floorplan()
+	if err != nil {
+		return nil, err
+	}
+	quantity.area = make([]float64, nc)
+	for i := uint(0); i < nc; i++ {
+		quantity.area[i] = floorplan[i].Area()
+	}
+	for i := uint(0); i < nc; i++ {
+		for j := i + 1; j < nc; j++ {
+			if floorplan[i].Adjacent(&floorplan[j]) {
+				quantity.neighbors = append(quantity.neighbors, [2]uint{i, j})
+				quantity.separation = append(quantity.separation,
+					floorplan[i].Distance(&floorplan[j]))
+			}
+		}
+	}
+	if len(quantity.neighbors) == 0 {
+		return nil, errors.New("the floorplan has no neighboring cores")
+	}
+
+	return quantity, nil
+}
+
+func (self *power) Compute(node, value []float64) {
+	nc := uint(self.system.Platform.Len())
+
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	P := self.system.ComputeDynamicPower(schedule, dynamic)
+	self.system.ComputeTemperatureUpdatePower(schedule, P, static)
+	ns := uint(len(P)) / nc
+
+	switch self.name {
+	case "peak-power":
+		value[0] = 0.0
+		for j := uint(0); j < ns; j++ {
+			value[0] = math.Max(value[0], support.Sum(P[j*nc:(j+1)*nc]))
+		}
+	case "core-peak-power":
+		for i := uint(0); i < nc; i++ {
+			value[i] = 0.0
+		}
+		for j := uint(0); j < ns; j++ {
+			for i := uint(0); i < nc; i++ {
+				value[i] = math.Max(value[i], P[j*nc+i])
+			}
+		}
+	case "average-power":
+		value[0] = 0.0
+		if ns > 0 {
+			value[0] = support.Sum(P) / float64(ns)
+		}
+	case "power-density-gradient":
+		value[0] = 0.0
+		for j := uint(0); j < ns; j++ {
+			for k, pair := range self.neighbors {
+				a, b := pair[0], pair[1]
+				Δ := P[j*nc+a]/self.area[a] - P[j*nc+b]/self.area[b]
+				value[0] = math.Max(value[0], math.Abs(Δ)/self.separation[k])
+			}
+		}
+	}
+}
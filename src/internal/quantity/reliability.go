@@ -0,0 +1,178 @@
+package quantity
+
+import (
+	"errors"
+	"math"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/system"
+	"github.com/turing-complete/laboratory/src/internal/uncertainty"
+)
+
+// Boltzmann’s constant in eV/K.
+const boltzmann = 8.617333262e-5
+
+// reliability is the mean time to failure of the platform due to thermal
+// cycling and electromigration. The failure rates of the cores and mechanisms
+// are summed up, and the temperature profile is assumed to repeat itself.
+type reliability struct {
+	base
+
+	model *config.Reliability
+}
+
+type cycle struct {
+	amplitude float64
+	maximum   float64
+	count     float64
+}
+
+func newReliability(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*reliability, error) {
+
+	model := &config.Reliability
+	if model.CyclingCoefficient < 0.0 || model.MigrationCoefficient < 0.0 {
+		return nil, errors.New("the coefficients of the reliability model should be nonnegative")
+	}
+	if model.CyclingCoefficient == 0.0 && model.MigrationCoefficient == 0.0 {
+		return nil, errors.New("the reliability model should have at least one failure mechanism")
+	}
+	if model.Lifetime <= 0.0 {
+		return nil, errors.New("the lifetime of the reliability model should be positive")
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &reliability{base: base, model: model}, nil
+}
+
+func (self *reliability) Compute(node, value []float64) {
+	nc, Δt := uint(self.system.Platform.Len()), self.system.TimeStep()
+
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	P := self.system.ComputeDynamicPower(schedule, dynamic)
+	Q := self.system.ComputeTemperatureUpdatePower(schedule, P, static)
+	ns := uint(len(Q)) / nc
+
+	span, rate := float64(ns)*Δt, 0.0
+	trace := make([]float64, ns)
+	for i := uint(0); i < nc; i++ {
+		for j := uint(0); j < ns; j++ {
+			trace[j] = Q[j*nc+i]
+		}
+		rate += self.cycling(trace) / span
+		rate += self.migration(trace) * Δt / span
+	}
+
+	value[0] = self.lifetime(rate)
+}
+
+// lifetime converts a failure rate into the mean time to failure, which is
+// bounded by the lifetime of the model.
+func (self *reliability) lifetime(rate float64) float64 {
+	if rate <= 0.0 {
+		return self.model.Lifetime
+	}
+	return math.Min(1.0/rate, self.model.Lifetime)
+}
+
+// cycling returns the damage caused by thermal cycling according to the
+// Coffin–Manson equation and Miner’s rule.
+func (self *reliability) cycling(trace []float64) float64 {
+	model := self.model
+	if model.CyclingCoefficient == 0.0 {
+		return 0.0
+	}
+
+	damage := 0.0
+	for _, c := range rainflow(trace) {
+		Δ := c.amplitude - model.CyclingThreshold
+		if Δ <= 0.0 {
+			continue
+		}
+		N := model.CyclingCoefficient * math.Pow(Δ, -model.CyclingExponent) *
+			math.Exp(model.CyclingEnergy/(boltzmann*c.maximum))
+		damage += c.count / N
+	}
+
+	return damage
+}
+
+// migration returns the sum of the failure rates caused by electromigration
+// according to Black’s equation.
+func (self *reliability) migration(trace []float64) float64 {
+	model := self.model
+	if model.MigrationCoefficient == 0.0 {
+		return 0.0
+	}
+
+	rate := 0.0
+	for _, q := range trace {
+		rate += math.Pow(model.MigrationCurrent, model.MigrationExponent) *
+			math.Exp(-model.MigrationEnergy/(boltzmann*q)) / model.MigrationCoefficient
+	}
+
+	return rate
+}
+
+// rainflow performs rainflow counting of the cycles in a trace. Unclosed
+// cycles are counted as halves.
+func rainflow(trace []float64) []cycle {
+	cycles := []cycle{}
+
+	record := func(a, b, count float64) {
+		cycles = append(cycles, cycle{
+			amplitude: math.Abs(a - b),
+			maximum:   math.Max(a, b),
+			count:     count,
+		})
+	}
+
+	stack := []float64{}
+	for _, x := range turn(trace) {
+		stack = append(stack, x)
+		for {
+			n := len(stack)
+			if n < 3 {
+				break
+			}
+			X, Y := math.Abs(stack[n-1]-stack[n-2]), math.Abs(stack[n-2]-stack[n-3])
+			if X < Y {
+				break
+			}
+			if n == 3 {
+				record(stack[0], stack[1], 0.5)
+				stack = stack[1:]
+			} else {
+				record(stack[n-3], stack[n-2], 1.0)
+				stack = append(stack[:n-3], stack[n-1])
+			}
+		}
+	}
+	for i := 1; i < len(stack); i++ {
+		record(stack[i-1], stack[i], 0.5)
+	}
+
+	return cycles
+}
+
+// turn returns the turning points of a trace.
+func turn(trace []float64) []float64 {
+	points := []float64{}
+	for _, x := range trace {
+		n := len(points)
+		if n > 0 && x == points[n-1] {
+			continue
+		}
+		if n > 1 && (points[n-1]-points[n-2])*(x-points[n-1]) > 0.0 {
+			points[n-1] = x
+			continue
+		}
+		points = append(points, x)
+	}
+	return points
+}
@@ -0,0 +1,38 @@
+package quantity
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestRainflow(t *testing.T) {
+	cycles := rainflow([]float64{-2.0, 1.0, -3.0, 5.0, -1.0, 3.0, -4.0, 4.0, -2.0})
+
+	counts := map[float64]float64{}
+	for _, c := range cycles {
+		counts[c.amplitude] += c.count
+	}
+
+	assert.Equal(counts, map[float64]float64{
+		3.0: 0.5,
+		4.0: 1.5,
+		6.0: 0.5,
+		8.0: 1.0,
+		9.0: 0.5,
+	}, t)
+}
+
+func TestTurn(t *testing.T) {
+	points := turn([]float64{0.0, 1.0, 2.0, 2.0, 1.0, 1.0, 0.0, 3.0})
+	assert.Equal(points, []float64{0.0, 2.0, 0.0, 3.0}, t)
+}
+
+func TestLifetime(t *testing.T) {
+	quantity := &reliability{model: &config.Reliability{Lifetime: 1e6}}
+
+	assert.Equal(quantity.lifetime(0.0), 1e6, t)
+	assert.Equal(quantity.lifetime(1e-9), 1e6, t)
+	assert.Equal(quantity.lifetime(1e-3), 1e3, t)
+}
@@ -1,6 +1,7 @@
 package quantity
 
 import (
+	"errors"
 	"math"
 
 	"github.com/turing-complete/laboratory/src/internal/config"
@@ -24,10 +25,64 @@ func newTemperature(system *system.System, uncertainty uncertainty.Uncertainty,
 }
 
 func (self *temperature) Compute(node, value []float64) {
-	P := self.system.ComputeDynamicPower(self.system.ComputeSchedule(self.Backward(node)))
-	Q := self.system.ComputeTemperatureUpdatePower(P)
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	P := self.system.ComputeDynamicPower(schedule, dynamic)
+	Q := self.system.ComputeTemperatureUpdatePower(schedule, P, static)
 	value[0] = 0.0
 	for _, q := range Q {
 		value[0] = math.Max(value[0], q)
 	}
 }
+
+// coreTemperature is the peak temperature of each core followed, optionally,
+// by a down-sampled temperature trace of each core.
+type coreTemperature struct {
+	base
+
+	nc uint
+	np uint
+}
+
+func newCoreTemperature(system *system.System, uncertainty uncertainty.Uncertainty,
+	config *config.Quantity) (*coreTemperature, error) {
+
+	nc, np := uint(system.Platform.Len()), config.Points
+	if np == 1 {
+		return nil, errors.New("the number of points should be either zero or at least two")
+	}
+
+	ni, _ := uncertainty.Mapping()
+	base, err := newBase(system, uncertainty, config, ni, nc*(1+np))
+	if err != nil {
+		return nil, err
+	}
+	return &coreTemperature{base: base, nc: nc, np: np}, nil
+}
+
+func (self *coreTemperature) Compute(node, value []float64) {
+	nc, np := self.nc, self.np
+
+	duration, dynamic, static := self.backward(node)
+	schedule := self.system.ComputeSchedule(duration)
+	P := self.system.ComputeDynamicPower(schedule, dynamic)
+	Q := self.system.ComputeTemperatureUpdatePower(schedule, P, static)
+	ns := uint(len(Q)) / nc
+
+	for i := range value {
+		value[i] = 0.0
+	}
+	for j := uint(0); j < ns; j++ {
+		for i := uint(0); i < nc; i++ {
+			value[i] = math.Max(value[i], Q[j*nc+i])
+		}
+	}
+
+	if np == 0 || ns == 0 {
+		return
+	}
+	for k := uint(0); k < np; k++ {
+		j := uint(float64(k)*float64(ns-1)/float64(np-1) + 0.5)
+		copy(value[(1+k)*nc:(2+k)*nc], Q[j*nc:(j+1)*nc])
+	}
+}
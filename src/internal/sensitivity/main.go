@@ -0,0 +1,152 @@
+package sensitivity
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	"github.com/turing-complete/laboratory/src/internal/support"
+)
+
+// Indices is a set of Sobol indices with confidence intervals laid out as in
+// solution.Analysis.
+type Indices struct {
+	FirstOrder []float64
+	Total      []float64
+
+	FirstOrderLower []float64
+	FirstOrderUpper []float64
+	TotalLower      []float64
+	TotalUpper      []float64
+}
+
+// Generate returns the points of A, B, and AB1, …, ABni, where ABi is A with
+// column i taken from B.
+func Generate(sampler string, ni, ns uint, seed int64) ([]float64, error) {
+	z, err := support.Sample(sampler, 2*ni, ns, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]float64, (ni+2)*ns*ni)
+	A, B := points[:ns*ni], points[ns*ni:2*ns*ni]
+	for k := uint(0); k < ns; k++ {
+		copy(A[k*ni:(k+1)*ni], z[k*2*ni:k*2*ni+ni])
+		copy(B[k*ni:(k+1)*ni], z[k*2*ni+ni:(k+1)*2*ni])
+	}
+	for i := uint(0); i < ni; i++ {
+		AB := points[(2+i)*ns*ni : (3+i)*ns*ni]
+		copy(AB, A)
+		for k := uint(0); k < ns; k++ {
+			AB[k*ni+i] = B[k*ni+i]
+		}
+	}
+
+	return points, nil
+}
+
+// Estimate computes the Sobol indices according to Saltelli et al. (2010) and
+// Jansen (1999) and their confidence intervals using nb bootstrap resamples.
+func Estimate(values []float64, ni, no, ns, nb uint, level float64,
+	seed int64) (*Indices, error) {
+
+	if uint(len(values)) != (ni+2)*ns*no {
+		return nil, errors.New("the number of values is invalid")
+	}
+	if level <= 0.0 || level >= 1.0 {
+		return nil, errors.New("the confidence level should be in (0, 1)")
+	}
+
+	all := make([]uint, ns)
+	for k := range all {
+		all[k] = uint(k)
+	}
+
+	first, total := estimate(values, all, ni, no, ns)
+
+	indices := &Indices{
+		FirstOrder: first,
+		Total:      total,
+
+		FirstOrderLower: make([]float64, ni*no),
+		FirstOrderUpper: make([]float64, ni*no),
+		TotalLower:      make([]float64, ni*no),
+		TotalUpper:      make([]float64, ni*no),
+	}
+	if nb == 0 {
+		copy(indices.FirstOrderLower, first)
+		copy(indices.FirstOrderUpper, first)
+		copy(indices.TotalLower, total)
+		copy(indices.TotalUpper, total)
+		return indices, nil
+	}
+
+	generator := rand.New(rand.NewSource(support.NewSeed(seed)))
+
+	firsts, totals := make([]float64, nb*ni*no), make([]float64, nb*ni*no)
+	sample := make([]uint, ns)
+	for b := uint(0); b < nb; b++ {
+		for k := range sample {
+			sample[k] = uint(generator.Intn(int(ns)))
+		}
+		first, total := estimate(values, sample, ni, no, ns)
+		for m := uint(0); m < ni*no; m++ {
+			firsts[m*nb+b], totals[m*nb+b] = first[m], total[m]
+		}
+	}
+
+	α := (1.0 - level) / 2.0
+	for m := uint(0); m < ni*no; m++ {
+		indices.FirstOrderLower[m], indices.FirstOrderUpper[m] =
+			quantile(firsts[m*nb:(m+1)*nb], α), quantile(firsts[m*nb:(m+1)*nb], 1.0-α)
+		indices.TotalLower[m], indices.TotalUpper[m] =
+			quantile(totals[m*nb:(m+1)*nb], α), quantile(totals[m*nb:(m+1)*nb], 1.0-α)
+	}
+
+	return indices, nil
+}
+
+func estimate(values []float64, sample []uint, ni, no, ns uint) ([]float64, []float64) {
+	A, B := values[:ns*no], values[ns*no:2*ns*no]
+	n := float64(len(sample))
+
+	first, total := make([]float64, ni*no), make([]float64, ni*no)
+	for j := uint(0); j < no; j++ {
+		mean, square := 0.0, 0.0
+		for _, k := range sample {
+			a, b := A[k*no+j], B[k*no+j]
+			mean += a + b
+			square += a*a + b*b
+		}
+		mean /= 2.0 * n
+		variance := square/(2.0*n) - mean*mean
+		if variance <= 0.0 {
+			continue
+		}
+
+		for i := uint(0); i < ni; i++ {
+			AB := values[(2+i)*ns*no : (3+i)*ns*no]
+			V, E := 0.0, 0.0
+			for _, k := range sample {
+				a, b, ab := A[k*no+j], B[k*no+j], AB[k*no+j]
+				V += b * (ab - a)
+				E += (a - ab) * (a - ab)
+			}
+			first[i*no+j] = V / n / variance
+			total[i*no+j] = E / (2.0 * n) / variance
+		}
+	}
+
+	return first, total
+}
+
+func quantile(data []float64, p float64) float64 {
+	sorted := append([]float64(nil), data...)
+	sort.Float64s(sorted)
+	position := p * float64(len(sorted)-1)
+	i := uint(position)
+	if i+1 >= uint(len(sorted)) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (position-float64(i))*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,61 @@
+package sensitivity
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestEstimate(t *testing.T) {
+	const (
+		ni = 2
+		no = 1
+		ns = 20000
+	)
+
+	generator := rand.New(rand.NewSource(42))
+
+	A, B := make([]float64, ns*ni), make([]float64, ns*ni)
+	for k := range A {
+		A[k], B[k] = generator.Float64(), generator.Float64()
+	}
+
+	compute := func(x []float64) float64 {
+		return x[0] + 2.0*x[1]
+	}
+
+	values := make([]float64, (ni+2)*ns*no)
+	for k := 0; k < ns; k++ {
+		values[k] = compute(A[k*ni : (k+1)*ni])
+		values[ns+k] = compute(B[k*ni : (k+1)*ni])
+		for i := 0; i < ni; i++ {
+			x := append([]float64(nil), A[k*ni:(k+1)*ni]...)
+			x[i] = B[k*ni+i]
+			values[(2+i)*ns+k] = compute(x)
+		}
+	}
+
+	indices, err := Estimate(values, ni, no, ns, 50, 0.95, 42)
+	assert.Success(err, t)
+
+	assert.Close(indices.FirstOrder, []float64{0.2, 0.8}, 0.03, t)
+	assert.Close(indices.Total, []float64{0.2, 0.8}, 0.03, t)
+	for i := 0; i < ni; i++ {
+		assert.Equal(indices.FirstOrderLower[i] <= indices.FirstOrder[i], true, t)
+		assert.Equal(indices.FirstOrderUpper[i] >= indices.FirstOrder[i], true, t)
+		assert.Equal(indices.TotalLower[i] <= indices.Total[i], true, t)
+		assert.Equal(indices.TotalUpper[i] >= indices.Total[i], true, t)
+	}
+
+	_, err = Estimate(values[1:], ni, no, ns, 50, 0.95, 42)
+	assert.Failure(err, t)
+}
+
+func TestQuantile(t *testing.T) {
+	data := []float64{4.0, 1.0, 3.0, 2.0, 5.0}
+	assert.Equal(quantile(data, 0.0), 1.0, t)
+	assert.Equal(quantile(data, 0.5), 3.0, t)
+	assert.Equal(quantile(data, 0.625), 3.5, t)
+	assert.Equal(quantile(data, 1.0), 5.0, t)
+}
@@ -0,0 +1,263 @@
+package solution
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/ready-steady/adapt/basis/polynomial"
+	"github.com/ready-steady/adapt/grid/equidistant"
+)
+
+// Analysis is a set of statistics of a surrogate. The Sobol index of input i
+// with respect to output j is at i*no + j.
+type Analysis struct {
+	Mean       []float64
+	Variance   []float64
+	FirstOrder []float64
+	Total      []float64
+}
+
+// quadrature is a one-dimensional quadrature rule that integrates exactly the
+// products of pairs of basis functions of a surrogate in one dimension.
+type quadrature struct {
+	grid interface {
+		Compute([]uint64) []float64
+	}
+	basis interface {
+		Compute([]uint64, []float64) float64
+	}
+	order uint
+}
+
+// Analyze computes the mean, variance, and Sobol indices of a surrogate by
+// integrating it piecewise using Gauss–Legendre quadrature.
+func (self *Solution) Analyze(surrogate *Surrogate) (*Analysis, error) {
+	rule, err := newQuadrature(self.config.Rule, self.config.Power)
+	if err != nil {
+		return nil, err
+	}
+	return analyze(surrogate, rule), nil
+}
+
+func analyze(surrogate *Surrogate, rule *quadrature) *Analysis {
+	ni, no, nn := surrogate.Inputs, surrogate.Outputs, surrogate.Nodes
+
+	// Integrals of individual basis functions and of their pairwise products
+	// in each dimension, computed for the distinct one-dimensional indices.
+	position := make([][]uint, ni)
+	integral := make([][]float64, ni)
+	product := make([][]float64, ni)
+	dimension := make([]uint, ni)
+	for i := uint(0); i < ni; i++ {
+		unique, index := distinguish(surrogate.Indices, i, ni, nn)
+		position[i], dimension[i] = index, uint(len(unique))
+		integral[i], product[i] = rule.integrate(unique)
+	}
+
+	Y := surrogate.Surpluses
+
+	mean := make([]float64, no)
+	for k := uint(0); k < nn; k++ {
+		w := 1.0
+		for i := uint(0); i < ni; i++ {
+			w *= integral[i][position[i][k]]
+		}
+		for j := uint(0); j < no; j++ {
+			mean[j] += w * Y[k*no+j]
+		}
+	}
+
+	// The second moment of the surrogate and of its conditional expectations
+	// given all inputs except one.
+	second := make([]float64, no)
+	excluded := make([]float64, ni*no)
+	prefix, suffix := make([]float64, ni+1), make([]float64, ni+1)
+	for k := uint(0); k < nn; k++ {
+		for m := k; m < nn; m++ {
+			prefix[0], suffix[ni] = 1.0, 1.0
+			for i := uint(0); i < ni; i++ {
+				p, q := position[i][k], position[i][m]
+				prefix[i+1] = prefix[i] * product[i][p*dimension[i]+q]
+			}
+			for i := ni; i > 0; i-- {
+				p, q := position[i-1][k], position[i-1][m]
+				suffix[i-1] = suffix[i] * product[i-1][p*dimension[i-1]+q]
+			}
+
+			factor := 2.0
+			if k == m {
+				factor = 1.0
+			}
+
+			for j := uint(0); j < no; j++ {
+				y := factor * Y[k*no+j] * Y[m*no+j]
+				second[j] += y * prefix[ni]
+				for i := uint(0); i < ni; i++ {
+					w := integral[i][position[i][k]] * integral[i][position[i][m]]
+					excluded[i*no+j] += y * w * prefix[i] * suffix[i+1]
+				}
+			}
+		}
+	}
+
+	variance := make([]float64, no)
+	for j := uint(0); j < no; j++ {
+		variance[j] = math.Max(second[j]-mean[j]*mean[j], 0.0)
+	}
+
+	// The variance of the conditional expectation given one input.
+	first := make([]float64, ni*no)
+	for i := uint(0); i < ni; i++ {
+		nu := dimension[i]
+		a := make([]float64, nu*no)
+		for k := uint(0); k < nn; k++ {
+			w := 1.0
+			for d := uint(0); d < ni; d++ {
+				if d != i {
+					w *= integral[d][position[d][k]]
+				}
+			}
+			p := position[i][k]
+			for j := uint(0); j < no; j++ {
+				a[p*no+j] += w * Y[k*no+j]
+			}
+		}
+		for j := uint(0); j < no; j++ {
+			sum := 0.0
+			for p := uint(0); p < nu; p++ {
+				for q := uint(0); q < nu; q++ {
+					sum += a[p*no+j] * a[q*no+j] * product[i][p*nu+q]
+				}
+			}
+			first[i*no+j] = sum - mean[j]*mean[j]
+		}
+	}
+
+	total := make([]float64, ni*no)
+	for i := uint(0); i < ni; i++ {
+		for j := uint(0); j < no; j++ {
+			if variance[j] == 0.0 {
+				first[i*no+j] = 0.0
+				continue
+			}
+			total[i*no+j] = 1.0 - (excluded[i*no+j]-mean[j]*mean[j])/variance[j]
+			first[i*no+j] /= variance[j]
+		}
+	}
+
+	return &Analysis{
+		Mean:       mean,
+		Variance:   variance,
+		FirstOrder: first,
+		Total:      total,
+	}
+}
+
+func newQuadrature(rule string, power uint) (*quadrature, error) {
+	switch rule {
+	case "closed":
+		return &quadrature{
+			grid:  equidistant.NewClosed(1),
+			basis: polynomial.NewClosed(1, power),
+			order: power + 1,
+		}, nil
+	case "open":
+		return &quadrature{
+			grid:  equidistant.NewOpen(1),
+			basis: polynomial.NewOpen(1, power),
+			order: power + 1,
+		}, nil
+	default:
+		return nil, errors.New("the interpolation rule is unknown")
+	}
+}
+
+// integrate returns the integrals of the basis functions with the given
+// indices and the matrix of the integrals of their pairwise products.
+func (self *quadrature) integrate(indices []uint64) ([]float64, []float64) {
+	nu := uint(len(indices))
+
+	breakpoints := append(self.grid.Compute(indices), 0.0, 1.0)
+	sort.Float64s(breakpoints)
+
+	x, w := legendre(self.order)
+	points, weights := []float64{}, []float64{}
+	for i := 1; i < len(breakpoints); i++ {
+		a, b := breakpoints[i-1], breakpoints[i]
+		if b-a <= 0.0 {
+			continue
+		}
+		for k := range x {
+			points = append(points, a+(b-a)*x[k])
+			weights = append(weights, (b-a)*w[k])
+		}
+	}
+
+	np := uint(len(points))
+	values := make([]float64, nu*np)
+	for p := uint(0); p < nu; p++ {
+		for k := uint(0); k < np; k++ {
+			values[p*np+k] = self.basis.Compute(indices[p:p+1], points[k:k+1])
+		}
+	}
+
+	integral, product := make([]float64, nu), make([]float64, nu*nu)
+	for p := uint(0); p < nu; p++ {
+		for k := uint(0); k < np; k++ {
+			integral[p] += weights[k] * values[p*np+k]
+		}
+		for q := p; q < nu; q++ {
+			sum := 0.0
+			for k := uint(0); k < np; k++ {
+				sum += weights[k] * values[p*np+k] * values[q*np+k]
+			}
+			product[p*nu+q], product[q*nu+p] = sum, sum
+		}
+	}
+
+	return integral, product
+}
+
+// distinguish returns the distinct indices in a dimension and the position of
+// the index of each node among them.
+func distinguish(indices []uint64, i, ni, nn uint) ([]uint64, []uint) {
+	unique, position := []uint64{}, make([]uint, nn)
+	mapping := make(map[uint64]uint)
+	for k := uint(0); k < nn; k++ {
+		index := indices[k*ni+i]
+		p, ok := mapping[index]
+		if !ok {
+			p = uint(len(unique))
+			mapping[index] = p
+			unique = append(unique, index)
+		}
+		position[k] = p
+	}
+	return unique, position
+}
+
+// legendre returns the nodes and weights of the Gauss–Legendre quadrature rule
+// with n nodes on [0, 1].
+func legendre(n uint) ([]float64, []float64) {
+	x, w := make([]float64, n), make([]float64, n)
+	for i := uint(0); i < n; i++ {
+		z := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var derivative float64
+		for k := 0; k < 100; k++ {
+			p0, p1 := 1.0, 0.0
+			for j := uint(0); j < n; j++ {
+				p0, p1 = ((2.0*float64(j)+1.0)*z*p0-float64(j)*p1)/(float64(j)+1.0), p0
+			}
+			derivative = float64(n) * (z*p0 - p1) / (z*z - 1.0)
+			Δ := p0 / derivative
+			z -= Δ
+			if math.Abs(Δ) < 1e-15 {
+				break
+			}
+		}
+		x[i] = (1.0 - z) / 2.0
+		w[i] = 1.0 / ((1.0 - z*z) * derivative * derivative)
+	}
+	return x, w
+}
@@ -0,0 +1,59 @@
+package solution
+
+import (
+	"testing"
+
+	"github.com/ready-steady/adapt/algorithm"
+	"github.com/ready-steady/assert"
+)
+
+type linearGrid struct{}
+
+func (linearGrid) Compute(indices []uint64) []float64 {
+	nodes := make([]float64, len(indices))
+	for i := range indices {
+		nodes[i] = 0.5 + 0.5*float64(indices[i])
+	}
+	return nodes
+}
+
+type linearBasis struct{}
+
+func (linearBasis) Compute(index []uint64, point []float64) float64 {
+	if index[0] == 0 {
+		return 1.0
+	}
+	return point[0]
+}
+
+func TestAnalyze(t *testing.T) {
+	// f(x, y) = 1 + 2 x + 3 x y
+	surrogate := &Surrogate{
+		Surrogate: algorithm.Surrogate{
+			Inputs:    2,
+			Outputs:   1,
+			Nodes:     3,
+			Indices:   []uint64{0, 0, 1, 0, 1, 1},
+			Surpluses: []float64{1.0, 2.0, 3.0},
+		},
+	}
+
+	analysis := analyze(surrogate, &quadrature{
+		grid:  linearGrid{},
+		basis: linearBasis{},
+		order: 2,
+	})
+
+	assert.Close(analysis.Mean, []float64{2.75}, 1e-14, t)
+	assert.Close(analysis.Variance, []float64{61.0 / 48.0}, 1e-14, t)
+	assert.Close(analysis.FirstOrder, []float64{49.0 / 61.0, 9.0 / 61.0}, 1e-14, t)
+	assert.Close(analysis.Total, []float64{52.0 / 61.0, 12.0 / 61.0}, 1e-14, t)
+}
+
+func TestLegendre(t *testing.T) {
+	x, w := legendre(3)
+	assert.Close(x, []float64{
+		1.1270166537925830e-01, 5.0000000000000000e-01, 8.8729833462074170e-01,
+	}, 1e-14, t)
+	assert.Close(w, []float64{5.0 / 18.0, 8.0 / 18.0, 5.0 / 18.0}, 1e-14, t)
+}
@@ -62,13 +62,37 @@ func New(ni, no uint, config *config.Solution) (*Solution, error) {
 	}, nil
 }
 
-func (self *Solution) Compute(target, reference quantity.Quantity) *Surrogate {
+// Compute constructs a surrogate. If save is given, a record of the
+// evaluations made so far is passed to it every Record steps and at the end,
+// and the construction stops as soon as save fails. If a record is given, the
+// construction starts from the beginning, but the quantity is not evaluated
+// at the recorded nodes; since the algorithm is deterministic, the result is
+// the same as the one of an uninterrupted construction.
+func (self *Solution) Compute(target, reference quantity.Quantity, record *Record,
+	save func(*Record) error) (*Surrogate, error) {
+
 	strategy := newStrategy(target, reference, self.grid, self.config)
-	surrogate := self.Algorithm.Compute(target.Compute, strategy)
+	strategy.save = save
+
+	compute := target.Compute
+	if record != nil {
+		compute = newCache(record, target).compute
+	}
+
+	surrogate := self.Algorithm.Compute(compute, strategy)
+	if strategy.err != nil {
+		return nil, strategy.err
+	}
+	if strategy.save != nil && strategy.interval > 0 {
+		if err := strategy.store(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Surrogate{
 		Surrogate:  *surrogate,
 		Statistics: Statistics{strategy.active},
-	}
+	}, nil
 }
 
 func (self *Solution) Evaluate(surrogate *Surrogate, nodes []float64) []float64 {
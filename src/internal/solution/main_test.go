@@ -21,7 +21,8 @@ func TestSolutionCompute(t *testing.T) {
 	ni, no := quantity.Dimensions()
 
 	solution, _ := New(ni, no, &config.Solution)
-	surrogate := solution.Compute(quantity, quantity)
+	surrogate, err := solution.Compute(quantity, quantity, nil, nil)
+	assert.Success(err, t)
 
 	nn := surrogate.Surrogate.Nodes
 
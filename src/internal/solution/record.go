@@ -0,0 +1,56 @@
+package solution
+
+import (
+	"math"
+
+	"github.com/turing-complete/laboratory/src/internal/quantity"
+)
+
+// Record is a record of the evaluations of the quantity made during the
+// construction of a surrogate, which includes the number of steps taken, the
+// nodes evaluated so far, and the corresponding values. It is not a snapshot
+// of the state of the algorithm.
+type Record struct {
+	Steps  uint
+	Nodes  []float64
+	Values []float64
+}
+
+type cache struct {
+	quantity quantity.Quantity
+	values   map[string][]float64
+}
+
+func newCache(record *Record, quantity quantity.Quantity) *cache {
+	ni, no := quantity.Dimensions()
+	nn := uint(len(record.Nodes)) / ni
+	if uint(len(record.Values)) < nn*no {
+		nn = uint(len(record.Values)) / no
+	}
+
+	values := make(map[string][]float64, nn)
+	for i := uint(0); i < nn; i++ {
+		values[key(record.Nodes[i*ni:(i+1)*ni])] = record.Values[i*no : (i+1)*no]
+	}
+
+	return &cache{quantity: quantity, values: values}
+}
+
+func (self *cache) compute(node, value []float64) {
+	if cached, ok := self.values[key(node)]; ok {
+		copy(value, cached)
+		return
+	}
+	self.quantity.Compute(node, value)
+}
+
+func key(node []float64) string {
+	buffer := make([]byte, 8*len(node))
+	for i, x := range node {
+		bits := math.Float64bits(x)
+		for j := 0; j < 8; j++ {
+			buffer[8*i+j] = byte(bits >> uint(8*j))
+		}
+	}
+	return string(buffer)
+}
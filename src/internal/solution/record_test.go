@@ -0,0 +1,92 @@
+package solution
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/quantity"
+	"github.com/turing-complete/laboratory/src/internal/system"
+	"github.com/turing-complete/laboratory/src/internal/uncertainty"
+)
+
+type counter struct {
+	quantity.Quantity
+	calls uint
+}
+
+func (self *counter) Compute(node, value []float64) {
+	self.calls++
+	self.Quantity.Compute(node, value)
+}
+
+type fake struct {
+	calls uint
+}
+
+func (self *fake) Dimensions() (uint, uint) {
+	return 2, 1
+}
+
+func (self *fake) Compute(node, value []float64) {
+	self.calls++
+	value[0] = node[0] + node[1]
+}
+
+func (self *fake) Evaluate(_ []float64) float64 {
+	return 1.0
+}
+
+func (self *fake) Forward(node []float64) []float64 {
+	return node
+}
+
+func (self *fake) Backward(node []float64) []float64 {
+	return node
+}
+
+func TestCache(t *testing.T) {
+	quantity := &fake{}
+	cache := newCache(&Record{
+		Nodes:  []float64{0.0, 0.5, 0.25, 0.75},
+		Values: []float64{42.0, 24.0},
+	}, quantity)
+
+	value := make([]float64, 1)
+
+	cache.compute([]float64{0.25, 0.75}, value)
+	assert.Equal(value, []float64{24.0}, t)
+	assert.Equal(quantity.calls, uint(0), t)
+
+	cache.compute([]float64{0.5, 0.5}, value)
+	assert.Equal(value, []float64{1.0}, t)
+	assert.Equal(quantity.calls, uint(1), t)
+}
+
+func TestSolutionResume(t *testing.T) {
+	config, _ := config.New("fixtures/002_020.json")
+	config.Solution.Record = 1
+	system, _ := system.New(&config.System)
+	uncertainty, _ := uncertainty.NewEpistemic(system, &config.Uncertainty)
+
+	quantity, _ := quantity.New(system, uncertainty, &config.Quantity)
+	ni, no := quantity.Dimensions()
+
+	solution, _ := New(ni, no, &config.Solution)
+
+	records := []Record{}
+	surrogate1, err := solution.Compute(quantity, quantity, nil, func(record *Record) error {
+		records = append(records, *record)
+		return nil
+	})
+	assert.Success(err, t)
+	assert.Equal(len(records) > 2, true, t)
+
+	record := &records[1]
+	counter := &counter{Quantity: quantity}
+	surrogate2, err := solution.Compute(counter, counter, record, nil)
+	assert.Success(err, t)
+
+	assert.Equal(surrogate2, surrogate1, t)
+	assert.Equal(counter.calls, surrogate1.Surrogate.Nodes-uint(len(record.Nodes))/ni, t)
+}
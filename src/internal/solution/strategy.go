@@ -22,6 +22,13 @@ type strategy struct {
 	nn uint
 
 	active []uint
+
+	nodes  []float64
+	values []float64
+
+	interval uint
+	save     func(*Record) error
+	err      error
 }
 
 func newStrategy(target, reference quantity.Quantity, guide hybrid.Guide,
@@ -36,6 +43,8 @@ func newStrategy(target, reference quantity.Quantity, guide hybrid.Guide,
 		reference: reference,
 
 		nmax: config.MaxEvaluations,
+
+		interval: config.Record,
 	}
 }
 
@@ -52,6 +61,16 @@ func (self *strategy) Next(state *algorithm.State,
 	self.nn += nn
 	self.active = append(self.active, nn)
 
+	if self.save != nil && self.interval > 0 {
+		self.nodes = append(self.nodes, state.Nodes...)
+		self.values = append(self.values, state.Values...)
+		if uint(len(self.active))%self.interval == 0 {
+			if self.err = self.store(); self.err != nil {
+				return nil
+			}
+		}
+	}
+
 	state = self.Strategy.Next(state, surrogate)
 	if state == nil {
 		return nil
@@ -70,6 +89,14 @@ func (self *strategy) Next(state *algorithm.State,
 	return state
 }
 
+func (self *strategy) store() error {
+	return self.save(&Record{
+		Steps:  self.ns,
+		Nodes:  self.nodes,
+		Values: self.values,
+	})
+}
+
 func (self *strategy) Score(element *algorithm.Element) float64 {
 	return maxAbsolute(element.Surplus) * element.Volume
 }
@@ -0,0 +1,131 @@
+package support
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Sample draws ns points from the unit hypercube of dimension ni using a
+// sampling method. The seed is interpreted as in NewSeed. The Halton sequence
+// is randomly shifted unless the seed is zero.
+func Sample(sampler string, ni, ns uint, seed int64) ([]float64, error) {
+	switch sampler {
+	case "", "sobol":
+		return Generate(ni, ns, seed), nil
+	case "scrambled-sobol":
+		points := Generate(ni, ns, seed)
+		scramble(points, ni, NewSeed(seed))
+		return points, nil
+	case "halton":
+		return halton(ni, ns, NewSeed(seed)), nil
+	case "latin-hypercube":
+		return latin(ni, ns, NewSeed(seed)), nil
+	case "monte-carlo":
+		generator := rand.New(rand.NewSource(NewSeed(seed)))
+		points := make([]float64, ni*ns)
+		for i := range points {
+			points[i] = generator.Float64()
+		}
+		return points, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("the sampler “%s” is unknown", sampler))
+	}
+}
+
+func halton(ni, ns uint, seed int64) []float64 {
+	bases := primes(ni)
+
+	shift := make([]float64, ni)
+	if seed != 0 {
+		generator := rand.New(rand.NewSource(seed))
+		for i := range shift {
+			shift[i] = generator.Float64()
+		}
+	}
+
+	points := make([]float64, ni*ns)
+	for k := uint(0); k < ns; k++ {
+		for i := uint(0); i < ni; i++ {
+			x := radicalInverse(k+1, bases[i]) + shift[i]
+			points[k*ni+i] = x - math.Floor(x)
+		}
+	}
+
+	return points
+}
+
+func latin(ni, ns uint, seed int64) []float64 {
+	generator := rand.New(rand.NewSource(seed))
+
+	points := make([]float64, ni*ns)
+	for i := uint(0); i < ni; i++ {
+		for k, stratum := range generator.Perm(int(ns)) {
+			points[uint(k)*ni+i] = (float64(stratum) + generator.Float64()) / float64(ns)
+		}
+	}
+
+	return points
+}
+
+// scramble applies nested uniform scrambling to the first 32 binary digits of
+// each coordinate. The random permutations of the digits are derived from a
+// hash of the seed, the dimension, and the preceding digits.
+func scramble(points []float64, ni uint, seed int64) {
+	const (
+		digits = 32
+		scale  = 1 << digits
+	)
+
+	for k := range points {
+		i := uint64(uint(k) % ni)
+
+		x := uint64(math.Min(points[k]*scale, scale-1))
+		y := uint64(0)
+		for d := uint(0); d < digits; d++ {
+			prefix := x >> (digits - d)
+			bit := (x >> (digits - 1 - d)) & 1
+			flip := mix(uint64(seed)^mix(i^mix(uint64(d)^mix(prefix)))) & 1
+			y = y<<1 | (bit ^ flip)
+		}
+
+		points[k] = float64(y) / scale
+	}
+}
+
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+func radicalInverse(k, base uint) float64 {
+	result, factor := 0.0, 1.0/float64(base)
+	for ; k > 0; k /= base {
+		result += float64(k%base) * factor
+		factor /= float64(base)
+	}
+	return result
+}
+
+func primes(n uint) []uint {
+	result := make([]uint, 0, n)
+	for candidate := uint(2); uint(len(result)) < n; candidate++ {
+		prime := true
+		for _, p := range result {
+			if p*p > candidate {
+				break
+			}
+			if candidate%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package support
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestSampleHalton(t *testing.T) {
+	points, err := Sample("halton", 2, 4, 0)
+	assert.Success(err, t)
+	assert.Close(points, []float64{
+		1.0 / 2.0, 1.0 / 3.0,
+		1.0 / 4.0, 2.0 / 3.0,
+		3.0 / 4.0, 1.0 / 9.0,
+		1.0 / 8.0, 4.0 / 9.0,
+	}, 1e-15, t)
+}
+
+func TestSampleLatinHypercube(t *testing.T) {
+	const (
+		ni = 3
+		ns = 20
+	)
+
+	points, err := Sample("latin-hypercube", ni, ns, 42)
+	assert.Success(err, t)
+
+	for i := 0; i < ni; i++ {
+		strata := make([]bool, ns)
+		for k := 0; k < ns; k++ {
+			strata[int(points[k*ni+i]*ns)] = true
+		}
+		for _, hit := range strata {
+			assert.Equal(hit, true, t)
+		}
+	}
+}
+
+func TestSampleScrambledSobol(t *testing.T) {
+	const (
+		m  = 4
+		ns = 1 << m
+	)
+
+	scrambled := func(seed int64) []float64 {
+		points := make([]float64, 2*ns)
+		for k := uint(0); k < ns; k++ {
+			points[2*k] = float64(k) / ns
+			points[2*k+1] = radicalInverse(k, 2)
+		}
+		scramble(points, 2, seed)
+		return points
+	}
+
+	one, another := scrambled(1), scrambled(2)
+
+	for _, points := range [][]float64{one, another} {
+		for a := uint(0); a <= m; a++ {
+			b := m - a
+			counts := make([]uint, ns)
+			for k := uint(0); k < ns; k++ {
+				x, y := points[2*k], points[2*k+1]
+				assert.Equal(x >= 0.0 && x < 1.0 && y >= 0.0 && y < 1.0, true, t)
+				counts[uint(x*float64(uint(1)<<a))<<b|uint(y*float64(uint(1)<<b))]++
+			}
+			for _, count := range counts {
+				assert.Equal(count, uint(1), t)
+			}
+		}
+	}
+
+	same := true
+	for i := range one {
+		if one[i] != another[i] {
+			same = false
+		}
+	}
+	assert.Equal(same, false, t)
+}
+
+func TestSampleUnknown(t *testing.T) {
+	_, err := Sample("grid", 2, 4, 0)
+	assert.Failure(err, t)
+}
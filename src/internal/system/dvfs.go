@@ -0,0 +1,82 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/support"
+)
+
+// dvfs is the effect of the operating points assigned to tasks relative to
+// the nominal operating points of the cores the tasks are mapped to.
+type dvfs struct {
+	time    []float64
+	power   []float64
+	voltage []float64
+}
+
+type level struct {
+	voltage   float64
+	frequency float64
+}
+
+func createDVFS(config *config.DVFS, mapping []uint, nc, nt uint) (*dvfs, error) {
+	if len(config.Assignment) == 0 {
+		return nil, nil
+	}
+	if uint(len(config.Assignment)) != nt {
+		return nil, errors.New("the assignment of operating points should have an entry for each task")
+	}
+
+	levels := make([][]level, nc)
+	nominal := make([]level, nc)
+	for i := range nominal {
+		nominal[i] = level{voltage: 1.0, frequency: 1.0}
+		levels[i] = []level{nominal[i]}
+	}
+
+	for _, group := range config.Levels {
+		cores, err := support.ParseNaturalIndex(group.Cores, 0, nc-1)
+		if err != nil {
+			return nil, err
+		}
+		nl := uint(len(group.Voltage))
+		if nl == 0 || uint(len(group.Frequency)) != nl {
+			return nil, errors.New("the voltages and frequencies of operating points should match")
+		}
+		if group.Nominal >= nl {
+			return nil, errors.New("the nominal operating point is invalid")
+		}
+		points := make([]level, nl)
+		for i := uint(0); i < nl; i++ {
+			if group.Voltage[i] <= 0.0 || group.Frequency[i] <= 0.0 {
+				return nil, errors.New("the voltages and frequencies of operating points should be positive")
+			}
+			points[i] = level{voltage: group.Voltage[i], frequency: group.Frequency[i]}
+		}
+		for _, j := range cores {
+			levels[j] = points
+			nominal[j] = points[group.Nominal]
+		}
+	}
+
+	result := &dvfs{
+		time:    make([]float64, nt),
+		power:   make([]float64, nt),
+		voltage: make([]float64, nt),
+	}
+	for i := uint(0); i < nt; i++ {
+		j, k := mapping[i], config.Assignment[i]
+		if k >= uint(len(levels[j])) {
+			return nil, errors.New(fmt.Sprintf("the operating point of task %d is invalid", i))
+		}
+		v := levels[j][k].voltage / nominal[j].voltage
+		f := levels[j][k].frequency / nominal[j].frequency
+		result.time[i] = 1.0 / f
+		result.power[i] = v * v * f
+		result.voltage[i] = v
+	}
+
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestCreateDVFS(t *testing.T) {
+	mapping := []uint{0, 1, 1}
+
+	dvfs, err := createDVFS(&config.DVFS{}, mapping, 2, 3)
+	assert.Success(err, t)
+	assert.Equal(dvfs == nil, true, t)
+
+	configuration := &config.DVFS{
+		Levels: []config.Levels{
+			{Cores: "[1]", Voltage: []float64{0.8, 1.0}, Frequency: []float64{1.0, 2.0}, Nominal: 1},
+		},
+		Assignment: []uint{0, 0, 1},
+	}
+
+	dvfs, err = createDVFS(configuration, mapping, 2, 3)
+	assert.Success(err, t)
+	assert.Close(dvfs.time, []float64{1.0, 2.0, 1.0}, 1e-15, t)
+	assert.Close(dvfs.power, []float64{1.0, 0.32, 1.0}, 1e-15, t)
+	assert.Close(dvfs.voltage, []float64{1.0, 0.8, 1.0}, 1e-15, t)
+
+	configuration.Assignment = []uint{1, 0, 1}
+	_, err = createDVFS(configuration, mapping, 2, 3)
+	assert.Failure(err, t)
+}
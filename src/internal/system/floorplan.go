@@ -0,0 +1,129 @@
+package system
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Block is a rectangular region of a floorplan.
+type Block struct {
+	Name   string
+	Width  float64
+	Height float64
+	Left   float64
+	Bottom float64
+}
+
+// Area returns the area of the block.
+func (self *Block) Area() float64 {
+	return self.Width * self.Height
+}
+
+// Distance returns the distance between the centers of two blocks.
+func (self *Block) Distance(other *Block) float64 {
+	x := (self.Left + self.Width/2.0) - (other.Left + other.Width/2.0)
+	y := (self.Bottom + self.Height/2.0) - (other.Bottom + other.Height/2.0)
+	return math.Sqrt(x*x + y*y)
+}
+
+// Adjacent checks if two blocks share an edge of nonzero length.
+func (self *Block) Adjacent(other *Block) bool {
+	const ε = 1e-12
+
+	overlap := func(a1, b1, a2, b2 float64) float64 {
+		return math.Min(b1, b2) - math.Max(a1, a2)
+	}
+
+	touch := func(a, b float64) bool {
+		return math.Abs(a-b) < ε
+	}
+
+	horizontal := overlap(self.Left, self.Left+self.Width, other.Left, other.Left+other.Width)
+	vertical := overlap(self.Bottom, self.Bottom+self.Height, other.Bottom, other.Bottom+other.Height)
+
+	if vertical > ε && (touch(self.Left+self.Width, other.Left) ||
+		touch(other.Left+other.Width, self.Left)) {
+		return true
+	}
+	if horizontal > ε && (touch(self.Bottom+self.Height, other.Bottom) ||
+		touch(other.Bottom+other.Height, self.Bottom)) {
+		return true
+	}
+
+	return false
+}
+
+func loadFloorplan(path string) ([]Block, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	blocks := []Block{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", line, path))
+		}
+
+		values := make([]float64, 4)
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(fields[1+i], 64); err != nil {
+				return nil, errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", line, path))
+			}
+		}
+
+		blocks = append(blocks, Block{
+			Name:   fields[0],
+			Width:  values[0],
+			Height: values[1],
+			Left:   values[2],
+			Bottom: values[3],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func matchFloorplan(blocks []Block, nc uint) ([]Block, error) {
+	floorplan := make([]Block, nc)
+	found := make([]bool, nc)
+	for _, block := range blocks {
+		name := strings.ToLower(block.Name)
+		if !strings.HasPrefix(name, "core") {
+			continue
+		}
+		i, err := strconv.ParseUint(name[4:], 10, 0)
+		if err != nil || uint(i) >= nc {
+			continue
+		}
+		if found[i] {
+			return nil, errors.New(fmt.Sprintf("the floorplan has several blocks for core %d", i))
+		}
+		floorplan[i], found[i] = block, true
+	}
+	for i := range found {
+		if !found[i] {
+			return nil, errors.New(fmt.Sprintf("the floorplan has no block for core %d", i))
+		}
+	}
+	return floorplan, nil
+}
@@ -0,0 +1,442 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/system"
+)
+
+// document is a JSON description of a platform and application. The arcs of
+// the application are identified by their indices.
+type document struct {
+	Platform    *platformDocument
+	Application *applicationDocument
+}
+
+type platformDocument struct {
+	Cores []coreDocument
+}
+
+type coreDocument struct {
+	Time  []float64
+	Power []float64
+}
+
+type applicationDocument struct {
+	Tasks []taskDocument
+	Arcs  []arcDocument
+}
+
+type taskDocument struct {
+	Type     uint
+	Deadline *float64
+}
+
+type arcDocument struct {
+	From     uint
+	To       uint
+	Quantity *float64
+}
+
+func load(config *config.System) (*system.Platform, *system.Application, *specification, error) {
+	format := config.Format
+	if len(format) == 0 {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(config.Specification)), ".")
+	}
+
+	switch format {
+	case "tgff":
+		return loadTGFF(config.Specification)
+	case "json":
+		document, err := readJSON(config.Specification)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if document.Platform == nil || document.Application == nil {
+			return nil, nil, nil, errors.New(fmt.Sprintf("the file “%s” should describe "+
+				"both the platform and application", config.Specification))
+		}
+		return construct(document.Platform, document.Application)
+	case "dot":
+		platform, err := loadPlatform(config.Platform)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		application, err := readDOT(config.Specification)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return construct(platform, application)
+	default:
+		return nil, nil, nil, errors.New("the format of the specification is unknown")
+	}
+}
+
+func loadTGFF(path string) (*system.Platform, *system.Application, *specification, error) {
+	platform, application, err := system.Load(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	specification, err := loadSpecification(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return platform, application, specification, nil
+}
+
+func loadPlatform(path string) (*platformDocument, error) {
+	if len(path) == 0 {
+		return nil, errors.New("the platform should be specified")
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".tgff" {
+		platform, _, err := system.Load(path)
+		if err != nil {
+			return nil, err
+		}
+		result := &platformDocument{}
+		for _, core := range platform.Cores {
+			result.Cores = append(result.Cores, coreDocument{Time: core.Time, Power: core.Power})
+		}
+		return result, nil
+	}
+
+	document, err := readJSON(path)
+	if err != nil {
+		return nil, err
+	}
+	if document.Platform == nil {
+		return nil, errors.New(fmt.Sprintf("the file “%s” should describe the platform", path))
+	}
+	return document.Platform, nil
+}
+
+func construct(description *platformDocument, graph *applicationDocument) (
+	*system.Platform, *system.Application, *specification, error) {
+
+	nc, nt := uint(len(description.Cores)), uint(len(graph.Tasks))
+	if nc == 0 || nt == 0 {
+		return nil, nil, nil, errors.New("the platform and application should not be empty")
+	}
+
+	platform := &system.Platform{Cores: make([]system.Core, nc)}
+	for i, core := range description.Cores {
+		if len(core.Time) != len(core.Power) {
+			return nil, nil, nil, errors.New(fmt.Sprintf("the execution times and power "+
+				"of core %d should have the same length", i))
+		}
+		platform.Cores[i] = system.Core{ID: uint(i), Time: core.Time, Power: core.Power}
+	}
+
+	application := &system.Application{Tasks: make([]system.Task, nt)}
+	specification := &specification{deadlines: make([]float64, nt)}
+	for i, task := range graph.Tasks {
+		for j, core := range platform.Cores {
+			if task.Type >= uint(len(core.Time)) {
+				return nil, nil, nil, errors.New(fmt.Sprintf("the type of task %d "+
+					"is not supported by core %d", i, j))
+			}
+		}
+		application.Tasks[i] = system.Task{ID: uint(i), Type: task.Type}
+		specification.deadlines[i] = math.Inf(1)
+		if task.Deadline != nil {
+			specification.deadlines[i] = *task.Deadline
+		}
+	}
+
+	for k, entry := range graph.Arcs {
+		from, to := entry.From, entry.To
+		if from >= nt || to >= nt || from == to {
+			return nil, nil, nil, errors.New(fmt.Sprintf("the arc %d is invalid", k))
+		}
+		application.Tasks[from].Children = append(application.Tasks[from].Children, to)
+		application.Tasks[to].Parents = append(application.Tasks[to].Parents, from)
+		specification.arcs = append(specification.arcs, arc{from: from, to: to, kind: uint(k)})
+		if entry.Quantity != nil {
+			if specification.quantities == nil {
+				specification.quantities = make(map[uint]float64)
+			}
+			specification.quantities[uint(k)] = *entry.Quantity
+		}
+	}
+
+	if !acyclic(application) {
+		return nil, nil, nil, errors.New("the application should not have cycles")
+	}
+
+	return platform, application, specification, nil
+}
+
+func acyclic(application *system.Application) bool {
+	nt := len(application.Tasks)
+	pending := make([]int, nt)
+	queue := []uint{}
+	for i, task := range application.Tasks {
+		pending[i] = len(task.Parents)
+		if pending[i] == 0 {
+			queue = append(queue, uint(i))
+		}
+	}
+	count := 0
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		count++
+		for _, j := range application.Tasks[i].Children {
+			pending[j]--
+			if pending[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+	return count == nt
+}
+
+func readJSON(path string) (*document, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	document := &document{}
+	if err := json.NewDecoder(file).Decode(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// readDOT reads an application from a Graphviz file. Tasks are nodes with a
+// “type” and, optionally, a “deadline” attribute, and arcs are edges with,
+// optionally, a “quantity” attribute. Tasks are indexed in the order of their
+// first appearance. Graph, node, and edge defaults are ignored.
+func readDOT(path string) (*applicationDocument, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := func(statement string) error {
+		return errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", statement, path))
+	}
+
+	content := uncomment(string(data))
+	begin, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if begin < 0 || end < begin {
+		return nil, errors.New(fmt.Sprintf("the file “%s” is not a graph", path))
+	}
+
+	application := &applicationDocument{}
+	index := make(map[string]uint)
+	typed := make(map[uint]bool)
+
+	lookup := func(name string) uint {
+		if i, ok := index[name]; ok {
+			return i
+		}
+		i := uint(len(application.Tasks))
+		index[name] = i
+		application.Tasks = append(application.Tasks, taskDocument{})
+		return i
+	}
+
+	for _, statement := range split(content[begin+1 : end]) {
+		body, attributes, err := separate(statement)
+		if err != nil {
+			return nil, invalid(statement)
+		}
+		if len(body) == 0 || strings.Contains(body, "=") {
+			continue
+		}
+		switch body {
+		case "graph", "node", "edge":
+			continue
+		}
+
+		names := strings.Split(body, "->")
+		for i := range names {
+			names[i] = unquote(strings.TrimSpace(names[i]))
+			if len(names[i]) == 0 {
+				return nil, invalid(statement)
+			}
+		}
+
+		if len(names) == 1 {
+			i := lookup(names[0])
+			for key, value := range attributes {
+				number, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, invalid(statement)
+				}
+				switch key {
+				case "type":
+					if number < 0.0 || number != math.Floor(number) {
+						return nil, invalid(statement)
+					}
+					application.Tasks[i].Type = uint(number)
+					typed[i] = true
+				case "deadline":
+					application.Tasks[i].Deadline = &number
+				}
+			}
+			continue
+		}
+
+		var quantity *float64
+		if value, ok := attributes["quantity"]; ok {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, invalid(statement)
+			}
+			quantity = &number
+		}
+		for k := 1; k < len(names); k++ {
+			application.Arcs = append(application.Arcs, arcDocument{
+				From:     lookup(names[k-1]),
+				To:       lookup(names[k]),
+				Quantity: quantity,
+			})
+		}
+	}
+
+	for name, i := range index {
+		if !typed[i] {
+			return nil, errors.New(fmt.Sprintf("the type of task “%s” is not specified", name))
+		}
+	}
+
+	return application, nil
+}
+
+func uncomment(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines[i] = ""
+		} else if j := strings.Index(line, "//"); j >= 0 {
+			lines[i] = line[:j]
+		}
+	}
+	content = strings.Join(lines, "\n")
+	for {
+		begin := strings.Index(content, "/*")
+		if begin < 0 {
+			break
+		}
+		end := strings.Index(content[begin:], "*/")
+		if end < 0 {
+			return content[:begin]
+		}
+		content = content[:begin] + " " + content[begin+end+2:]
+	}
+	return content
+}
+
+// split splits the body of a graph into statements, which are separated by
+// semicolons or new lines outside of attribute lists and quotes.
+func split(content string) []string {
+	statements := []string{}
+	depth, quoted, start := 0, false, 0
+	for i, c := range content {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case (c == ';' || c == '\n') && depth == 0:
+			if statement := strings.TrimSpace(content[start:i]); len(statement) > 0 {
+				statements = append(statements, statement)
+			}
+			start = i + 1
+		}
+	}
+	if statement := strings.TrimSpace(content[start:]); len(statement) > 0 {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// separate separates a statement into its body and attributes.
+func separate(statement string) (string, map[string]string, error) {
+	attributes := make(map[string]string)
+	begin := strings.Index(statement, "[")
+	if begin < 0 {
+		return strings.TrimSpace(statement), attributes, nil
+	}
+	end := strings.LastIndex(statement, "]")
+	if end < begin {
+		return "", nil, errors.New("the attribute list is not closed")
+	}
+	fields := strings.FieldsFunc(statement[begin+1:end], func(c rune) bool {
+		return c == ',' || c == ';' || c == ' ' || c == '\t' || c == '\n'
+	})
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return "", nil, errors.New("the attribute is invalid")
+		}
+		attributes[strings.ToLower(strings.TrimSpace(parts[0]))] = unquote(strings.TrimSpace(parts[1]))
+	}
+	return strings.TrimSpace(statement[:begin]), attributes, nil
+}
+
+func unquote(value string) string {
+	if len(value) > 1 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Export writes the platform and application to a JSON file.
+func (self *System) Export(path string) error {
+	document := &document{
+		Platform:    &platformDocument{},
+		Application: &applicationDocument{},
+	}
+
+	for _, core := range self.Platform.Cores {
+		document.Platform.Cores = append(document.Platform.Cores,
+			coreDocument{Time: core.Time, Power: core.Power})
+	}
+
+	for i, task := range self.Application.Tasks {
+		entry := taskDocument{Type: task.Type}
+		if deadline := self.specification.deadlines[i]; !math.IsInf(deadline, 1) {
+			entry.Deadline = &deadline
+		}
+		document.Application.Tasks = append(document.Application.Tasks, entry)
+	}
+
+	for _, arc := range self.specification.arcs {
+		entry := arcDocument{From: arc.from, To: arc.to}
+		if quantity, ok := self.specification.quantities[arc.kind]; ok {
+			entry.Quantity = &quantity
+		}
+		document.Application.Arcs = append(document.Application.Arcs, entry)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := json.MarshalIndent(document, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
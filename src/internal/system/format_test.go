@@ -0,0 +1,74 @@
+package system
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestLoadJSON(t *testing.T) {
+	platform, application, specification, err := load(&config.System{
+		Specification: "fixtures/002_004.json",
+	})
+	assert.Success(err, t)
+
+	assert.Equal(len(platform.Cores), 2, t)
+	assert.Equal(platform.Cores[1].Power, []float64{10.0, 20.0, 15.0, 10.0}, t)
+	assert.Equal(len(application.Tasks), 4, t)
+	assert.Equal(application.Tasks[0].Children, []uint{1, 2}, t)
+	assert.Equal(application.Tasks[3].Parents, []uint{1, 2}, t)
+	assert.Equal(application.Tasks[3].Type, uint(3), t)
+	assert.Equal(specification.quantities, map[uint]float64{0: 4, 1: 1, 2: 2, 3: 8}, t)
+	assert.Equal(specification.deadlines[3], 0.1, t)
+}
+
+func TestLoadDOT(t *testing.T) {
+	platform1, application1, specification1, err := load(&config.System{
+		Specification: "fixtures/002_004.json",
+	})
+	assert.Success(err, t)
+
+	platform2, application2, specification2, err := load(&config.System{
+		Specification: "fixtures/002_004.dot",
+		Platform:      "fixtures/002_004.json",
+	})
+	assert.Success(err, t)
+
+	assert.Equal(platform2, platform1, t)
+	assert.Equal(application2, application1, t)
+	assert.Equal(specification2, specification1, t)
+
+	_, _, _, err = load(&config.System{Specification: "fixtures/002_004.dot"})
+	assert.Failure(err, t)
+}
+
+func TestExport(t *testing.T) {
+	platform, application, specification, _ := load(&config.System{
+		Specification: "fixtures/002_004.json",
+	})
+
+	directory, _ := ioutil.TempDir("", "system")
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "system.json")
+
+	system := &System{Platform: platform, Application: application, specification: specification}
+	assert.Success(system.Export(path), t)
+
+	platform2, application2, specification2, err := load(&config.System{Specification: path})
+	assert.Success(err, t)
+	assert.Equal(platform2, platform, t)
+	assert.Equal(application2, application, t)
+	assert.Equal(specification2, specification, t)
+
+	path2 := filepath.Join(directory, "system2.json")
+	system = &System{Platform: platform2, Application: application2, specification: specification2}
+	assert.Success(system.Export(path2), t)
+
+	data, _ := ioutil.ReadFile(path)
+	data2, _ := ioutil.ReadFile(path2)
+	assert.Equal(string(data2), string(data), t)
+}
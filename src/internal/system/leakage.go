@@ -0,0 +1,147 @@
+package system
+
+import (
+	"errors"
+	"log"
+	"math"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/power/static"
+)
+
+// leakage is a static-power model. Compute returns the static power of a core
+// given its supply voltage relative to the nominal one and its temperature.
+type leakage interface {
+	Compute(float64, float64) float64
+}
+
+// tabular is a model fitted to a table of temperatures and coefficients.
+type tabular struct {
+	power *static.Power
+}
+
+// exponential is a model of the form
+//
+// P(T) = P0 exp(β (T - T0)).
+type exponential struct {
+	nominal   float64
+	exponent  float64
+	reference float64
+}
+
+// polynomial is a model of the form
+//
+// P(T) = P0 (c0 + c1 (T - T0) + c2 (T - T0)^2 + …) / c0.
+type polynomial struct {
+	nominal     float64
+	coefficient []float64
+	reference   float64
+}
+
+// bsim is a model based on the subthreshold current:
+//
+// P(V, T) = P0 g(V, T) / g(V0, T0) where g(V, T) = V T^2 exp((a V - b) / T).
+type bsim struct {
+	nominal   float64
+	slope     float64
+	threshold float64
+	reference float64
+	voltage   float64
+}
+
+// zero is a model with no static power.
+type zero struct{}
+
+func createLeakage(nominal float64, config *config.StaticPower) (leakage, error) {
+	if nominal == 0.0 {
+		log.Printf("The static-power model is disabled.")
+		return zero{}, nil
+	}
+
+	switch config.Model {
+	case "", "table":
+		T, C := config.Temperature, config.Coefficient
+		if len(T) < 2 || len(T) != len(C) {
+			return nil, errors.New("the temperatures and coefficients of the static " +
+				"power should have the same length of at least two")
+		}
+		for i := 1; i < len(T); i++ {
+			if T[i] <= T[i-1] {
+				return nil, errors.New("the temperatures of the static power should be increasing")
+			}
+		}
+		return &tabular{power: static.New(nominal, T, C)}, nil
+	case "exponential":
+		if len(config.Coefficient) != 1 {
+			return nil, errors.New("the exponential static power should have one coefficient")
+		}
+		if config.Reference <= 0.0 {
+			return nil, errors.New("the reference temperature of the static power should be positive")
+		}
+		return &exponential{
+			nominal:   nominal,
+			exponent:  config.Coefficient[0],
+			reference: config.Reference,
+		}, nil
+	case "polynomial":
+		if len(config.Coefficient) == 0 || config.Coefficient[0] <= 0.0 {
+			return nil, errors.New("the polynomial static power should have a positive constant term")
+		}
+		if config.Reference <= 0.0 {
+			return nil, errors.New("the reference temperature of the static power should be positive")
+		}
+		return &polynomial{
+			nominal:     nominal,
+			coefficient: config.Coefficient,
+			reference:   config.Reference,
+		}, nil
+	case "bsim":
+		if len(config.Coefficient) != 2 {
+			return nil, errors.New("the BSIM static power should have two coefficients")
+		}
+		if config.Reference <= 0.0 {
+			return nil, errors.New("the reference temperature of the static power should be positive")
+		}
+		if config.Voltage <= 0.0 {
+			return nil, errors.New("the nominal voltage of the static power should be positive")
+		}
+		return &bsim{
+			nominal:   nominal,
+			slope:     config.Coefficient[0],
+			threshold: config.Coefficient[1],
+			reference: config.Reference,
+			voltage:   config.Voltage,
+		}, nil
+	default:
+		return nil, errors.New("the static-power model is unknown")
+	}
+}
+
+func (self *tabular) Compute(V, T float64) float64 {
+	return V * self.power.Compute(T)
+}
+
+func (self *exponential) Compute(V, T float64) float64 {
+	return V * self.nominal * math.Exp(self.exponent*(T-self.reference))
+}
+
+func (self *polynomial) Compute(V, T float64) float64 {
+	c, Δ := self.coefficient, T-self.reference
+	sum := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		sum = sum*Δ + c[i]
+	}
+	return V * self.nominal * sum / c[0]
+}
+
+func (self *bsim) Compute(V, T float64) float64 {
+	V0, T0 := self.voltage, self.reference
+	g := func(V, T float64) float64 {
+		return V * T * T * math.Exp((self.slope*V-self.threshold)/T)
+	}
+	return self.nominal * g(V*V0, T) / g(V0, T0)
+}
+
+func (zero) Compute(float64, float64) float64 {
+	return 0.0
+}
@@ -0,0 +1,55 @@
+package system
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestCreateLeakage(t *testing.T) {
+	cases := []struct {
+		config  config.StaticPower
+		success bool
+	}{
+		{config.StaticPower{Temperature: []float64{300, 400}, Coefficient: []float64{1, 2}}, true},
+		{config.StaticPower{Temperature: []float64{300, 400}, Coefficient: []float64{1}}, false},
+		{config.StaticPower{Temperature: []float64{400, 300}, Coefficient: []float64{1, 2}}, false},
+		{config.StaticPower{}, false},
+		{config.StaticPower{Model: "exponential", Coefficient: []float64{0.01}, Reference: 350}, true},
+		{config.StaticPower{Model: "exponential", Coefficient: []float64{0.01}}, false},
+		{config.StaticPower{Model: "polynomial", Coefficient: []float64{1, 0.1}, Reference: 350}, true},
+		{config.StaticPower{Model: "polynomial", Coefficient: []float64{0, 0.1}, Reference: 350}, false},
+		{config.StaticPower{Model: "bsim", Coefficient: []float64{1000, 2000}, Reference: 350, Voltage: 1.2}, true},
+		{config.StaticPower{Model: "bsim", Coefficient: []float64{1000, 2000}, Reference: 350}, false},
+		{config.StaticPower{Model: "cubic"}, false},
+	}
+
+	for _, c := range cases {
+		_, err := createLeakage(1.0, &c.config)
+		if c.success {
+			assert.Success(err, t)
+		} else {
+			assert.Failure(err, t)
+		}
+	}
+
+	leakage, err := createLeakage(0.0, &config.StaticPower{Model: "cubic"})
+	assert.Success(err, t)
+	assert.Equal(leakage.Compute(1.0, 350.0), 0.0, t)
+}
+
+func TestLeakageCompute(t *testing.T) {
+	exponential := &exponential{nominal: 2.0, exponent: 0.01, reference: 350.0}
+	assert.Close(exponential.Compute(1.0, 350.0), 2.0, 1e-15, t)
+	assert.Close(exponential.Compute(0.5, 360.0), math.Exp(0.1), 1e-15, t)
+
+	polynomial := &polynomial{nominal: 2.0, coefficient: []float64{2.0, 0.1, 0.01}, reference: 350.0}
+	assert.Close(polynomial.Compute(1.0, 350.0), 2.0, 1e-15, t)
+	assert.Close(polynomial.Compute(1.0, 360.0), 4.0, 1e-14, t)
+
+	bsim := &bsim{nominal: 2.0, slope: 1000.0, threshold: 2000.0, reference: 350.0, voltage: 1.2}
+	assert.Close(bsim.Compute(1.0, 350.0), 2.0, 1e-15, t)
+	assert.Close(bsim.Compute(0.5, 350.0), math.Exp(-600.0/350.0), 1e-14, t)
+}
@@ -3,39 +3,43 @@ package system
 import (
 	"errors"
 	"fmt"
-	"log"
 
 	"github.com/turing-complete/laboratory/src/internal/config"
 	"github.com/turing-complete/laboratory/src/internal/support"
 	"github.com/turing-complete/power/dynamic"
-	"github.com/turing-complete/power/static"
 	"github.com/turing-complete/system"
 	"github.com/turing-complete/time"
-
-	temperature "github.com/turing-complete/temperature/analytic"
 )
 
 type System struct {
 	Platform    *system.Platform
 	Application *system.Application
 
-	time         *time.List
+	specification *specification
+	floorplan     string
+
+	policy       policy
 	schedule     *time.Schedule
 	dynamicPower *dynamic.Power
-	staticPower  *static.Power
-	temperature  *temperature.Fixed
+	staticPower  leakage
+	temperature  thermal
+	dvfs         *dvfs
+
+	iterations uint
 
 	Δt float64
 }
 
 func New(config *config.System) (*System, error) {
-	platform, application, err := system.Load(config.Specification)
+	platform, application, specification, err := load(config)
 	if err != nil {
 		return nil, err
 	}
 
-	time := time.NewList(platform, application)
-	schedule := time.Compute(system.NewProfile(platform, application).Mobility)
+	list := time.NewList(platform, application)
+	priority := system.NewProfile(platform, application).Mobility
+	schedule := list.Compute(priority)
+
 	dynamicPower := dynamic.New(platform, application)
 
 	staticPower, err := createStaticPower(dynamicPower, schedule, &config.StaticPower)
@@ -43,7 +47,18 @@ func New(config *config.System) (*System, error) {
 		return nil, err
 	}
 
-	temperature, err := temperature.NewFixed(&config.Config)
+	temperature, iterations, err := createThermal(config, uint(platform.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	dvfs, err := createDVFS(&config.DVFS, schedule.Mapping,
+		uint(platform.Len()), uint(application.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := createPolicy(config.Policy, platform, application, list, priority, dvfs)
 	if err != nil {
 		return nil, err
 	}
@@ -52,33 +67,126 @@ func New(config *config.System) (*System, error) {
 		Platform:    platform,
 		Application: application,
 
-		time:         time,
+		specification: specification,
+		floorplan:     config.Floorplan,
+
+		policy:       policy,
 		schedule:     schedule,
 		dynamicPower: dynamicPower,
 		staticPower:  staticPower,
 		temperature:  temperature,
+		dvfs:         dvfs,
+
+		iterations: iterations,
 
 		Δt: config.TimeStep,
 	}, nil
 }
 
-func (self *System) ComputeDynamicPower(schedule *time.Schedule) []float64 {
-	return computeDynamicPower(self.dynamicPower, schedule, self.Δt)
+// ComputeCommunication returns the matrix of the quantities of data
+// transferred between pairs of tasks.
+func (self *System) ComputeCommunication() ([]float64, error) {
+	quantities := self.specification.quantities
+	if quantities == nil {
+		return nil, errors.New("the communication quantities are not specified")
+	}
+
+	nt := uint(self.Application.Len())
+	volume := make([]float64, nt*nt)
+	for _, arc := range self.specification.arcs {
+		quantity, ok := quantities[arc.kind]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("the communication quantity of "+
+				"type %d is not specified", arc.kind))
+		}
+		volume[arc.from*nt+arc.to] += quantity
+		volume[arc.to*nt+arc.from] += quantity
+	}
+
+	return volume, nil
+}
+
+// ComputeDynamicPower returns the dynamic power profile of a schedule. If
+// scale is not nil, the power of each task is multiplied by the corresponding
+// element of scale. In the transient thermal mode, the profile is repeated for
+// each iteration of the application.
+func (self *System) ComputeDynamicPower(schedule *time.Schedule, scale []float64) []float64 {
+	P := computeDynamicPower(self.dynamicPower, schedule, self.Δt)
+	if self.dvfs != nil {
+		if scale == nil {
+			scale = self.dvfs.power
+		} else {
+			scale = multiply(scale, self.dvfs.power)
+		}
+	}
+	if scale != nil {
+		rescaleDynamicPower(P, schedule, scale, uint(self.Platform.Len()), self.Δt)
+	}
+	if self.iterations > 1 {
+		P = repeat(P, self.iterations)
+	}
+	return P
 }
 
+// ComputeSchedule returns the schedule corresponding to the execution times of
+// tasks at the nominal operating points. The execution times are adjusted
+// according to the operating points assigned to the tasks.
 func (self *System) ComputeSchedule(duration []float64) *time.Schedule {
-	return self.time.Update(self.schedule, duration)
+	if self.dvfs != nil {
+		duration = multiply(duration, self.dvfs.time)
+	}
+	return self.policy.Compute(self.schedule, duration)
 }
 
-func (self *System) ComputeTemperatureUpdatePower(P []float64) []float64 {
+// ComputeTemperatureUpdatePower returns the temperature profile corresponding
+// to a dynamic power profile of a schedule and adds the static power to the
+// latter. If scale is not nil, the static power of each core is multiplied by
+// the corresponding element of scale.
+func (self *System) ComputeTemperatureUpdatePower(schedule *time.Schedule,
+	P []float64, scale []float64) []float64 {
+
 	nc := uint(self.Platform.Len())
-	return self.temperature.ComputeWithStatic(P, func(Q, P []float64) {
+
+	var V []float64
+	if self.dvfs != nil {
+		V = computeVoltage(schedule, self.dvfs.voltage, nc, uint(len(P))/nc, self.Δt)
+	}
+
+	return self.temperature.ComputeWithStatic(P, func(k uint, Q, P []float64) {
 		for i := uint(0); i < nc; i++ {
-			P[i] += self.staticPower.Compute(Q[i])
+			factor, voltage := 1.0, 1.0
+			if scale != nil {
+				factor = scale[i]
+			}
+			if V != nil {
+				voltage = V[k*nc+i]
+			}
+			P[i] += factor * self.staticPower.Compute(voltage, Q[i])
 		}
 	})
 }
 
+// Floorplan returns the blocks of the floorplan that are occupied by the
+// cores, one per core. The block of core i is the one named “core<i>,” and the
+// other blocks, such as caches and heat spreaders, are ignored.
+func (self *System) Floorplan() ([]Block, error) {
+	blocks, err := loadFloorplan(self.floorplan)
+	if err != nil {
+		return nil, err
+	}
+	return matchFloorplan(blocks, uint(self.Platform.Len()))
+}
+
+// Deadlines returns the hard deadlines of tasks. Tasks without deadlines have
+// infinite ones.
+func (self *System) Deadlines() []float64 {
+	return self.specification.deadlines
+}
+
+func (self *System) ReferenceSchedule() *time.Schedule {
+	return self.schedule
+}
+
 func (self *System) ReferenceTime() []float64 {
 	return self.schedule.Duration()
 }
@@ -93,7 +201,7 @@ func (self *System) String() string {
 }
 
 func createStaticPower(dynamicPower *dynamic.Power, schedule *time.Schedule,
-	config *config.StaticPower) (*static.Power, error) {
+	config *config.StaticPower) (leakage, error) {
 
 	if config.Contribution < 0.0 || config.Contribution >= 1.0 {
 		return nil, errors.New("the contribution of the static power is invalid")
@@ -102,13 +210,7 @@ func createStaticPower(dynamicPower *dynamic.Power, schedule *time.Schedule,
 	nominal := config.Contribution / (1.0 - config.Contribution) *
 		support.Average(dynamicPower.Distribute(schedule))
 
-	if nominal == 0.0 || len(config.Temperature) == 0 || len(config.Coefficient) == 0 {
-		log.Printf("The static-power model is disabled.")
-		config.Temperature = []float64{0.0, 1.0}
-		config.Coefficient = []float64{0.0, 0.0}
-	}
-
-	return static.New(nominal, config.Temperature, config.Coefficient), nil
+	return createLeakage(nominal, config)
 }
 
 func computeDynamicPower(dynamicPower *dynamic.Power,
@@ -116,3 +218,71 @@ func computeDynamicPower(dynamicPower *dynamic.Power,
 
 	return dynamicPower.Sample(schedule, Δt, uint(schedule.Span/Δt))
 }
+
+func rescaleDynamicPower(P []float64, schedule *time.Schedule,
+	scale []float64, nc uint, Δt float64) {
+
+	ns := uint(len(P)) / nc
+	for i := range scale {
+		if scale[i] == 1.0 {
+			continue
+		}
+		j := schedule.Mapping[i]
+		for s, f := locate(schedule, uint(i), ns, Δt); s < f; s++ {
+			P[s*nc+j] *= scale[i]
+		}
+	}
+}
+
+// computeVoltage returns the voltages of the cores relative to the nominal
+// ones at each time step. The profile is repeated with a period equal to the
+// span of the schedule.
+func computeVoltage(schedule *time.Schedule, voltage []float64,
+	nc, ns uint, Δt float64) []float64 {
+
+	np := uint(schedule.Span / Δt)
+
+	V := make([]float64, nc*ns)
+	for i := range V {
+		V[i] = 1.0
+	}
+	if np == 0 {
+		return V
+	}
+	for i := range voltage {
+		j := schedule.Mapping[i]
+		for s, f := locate(schedule, uint(i), np, Δt); s < f; s++ {
+			for k := s; k < ns; k += np {
+				V[k*nc+j] = voltage[i]
+			}
+		}
+	}
+
+	return V
+}
+
+// locate returns the range of time steps during which a task is executed.
+func locate(schedule *time.Schedule, i, ns uint, Δt float64) (uint, uint) {
+	s, f := uint(schedule.Start[i]/Δt), uint(schedule.Finish[i]/Δt)
+	if f > ns {
+		f = ns
+	}
+	return s, f
+}
+
+func multiply(a, b []float64) []float64 {
+	c := make([]float64, len(a))
+	for i := range a {
+		c[i] = a[i] * b[i]
+	}
+	return c
+}
+
+func repeat(P []float64, count uint) []float64 {
+	n := uint(len(P))
+	R := make([]float64, count*n)
+	for i := uint(0); i < count; i++ {
+		copy(R[i*n:(i+1)*n], P)
+	}
+	return R
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"math"
 	"testing"
 
 	"github.com/ready-steady/assert"
@@ -29,3 +30,63 @@ func TestNew(t *testing.T) {
 	}, 1e-15, t)
 	assert.Close(schedule.Span, 0.291, 1e-15, t)
 }
+
+func TestLoadSpecification(t *testing.T) {
+	specification, err := loadSpecification("fixtures/002_004.tgff")
+	assert.Success(err, t)
+
+	assert.Equal(specification.arcs, []arc{
+		{from: 0, to: 1, kind: 0},
+		{from: 0, to: 2, kind: 1},
+		{from: 1, to: 3, kind: 2},
+		{from: 2, to: 3, kind: 3},
+	}, t)
+	assert.Equal(specification.quantities, map[uint]float64{
+		0: 4.0, 1: 1.0, 2: 2.0, 3: 8.0,
+	}, t)
+	assert.Equal(specification.deadlines, []float64{
+		math.Inf(1), math.Inf(1), math.Inf(1), 0.1,
+	}, t)
+
+	specification, err = loadSpecification("fixtures/002_020.tgff")
+	assert.Success(err, t)
+	assert.Equal(len(specification.arcs), 23, t)
+	assert.Equal(specification.quantities == nil, true, t)
+	assert.Equal(specification.deadlines[6], 4.0, t)
+	assert.Equal(specification.deadlines[0], math.Inf(1), t)
+}
+
+func TestLoadFloorplan(t *testing.T) {
+	floorplan, err := loadFloorplan("fixtures/002.flp")
+	assert.Success(err, t)
+
+	assert.Equal(floorplan, []Block{
+		{Name: "core0", Width: 0.002, Height: 0.002, Left: 0.000, Bottom: 0.000},
+		{Name: "core1", Width: 0.002, Height: 0.002, Left: 0.002, Bottom: 0.000},
+	}, t)
+	assert.Equal(floorplan[0].Adjacent(&floorplan[1]), true, t)
+	assert.Close(floorplan[0].Distance(&floorplan[1]), 0.002, 1e-15, t)
+
+	block := Block{Width: 0.002, Height: 0.002, Left: 0.002, Bottom: 0.002}
+	assert.Equal(floorplan[0].Adjacent(&block), false, t)
+	assert.Equal(floorplan[1].Adjacent(&block), true, t)
+}
+
+func TestMatchFloorplan(t *testing.T) {
+	blocks := []Block{
+		{Name: "L2", Width: 0.004},
+		{Name: "core1", Width: 0.001},
+		{Name: "Core0", Width: 0.002},
+		{Name: "core2", Width: 0.003},
+	}
+
+	floorplan, err := matchFloorplan(blocks, 2)
+	assert.Success(err, t)
+	assert.Equal(floorplan, []Block{blocks[2], blocks[1]}, t)
+
+	_, err = matchFloorplan(blocks[:2], 2)
+	assert.Failure(err, t)
+
+	_, err = matchFloorplan(append(blocks, Block{Name: "core0"}), 2)
+	assert.Failure(err, t)
+}
@@ -0,0 +1,151 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+// policy is a scheduling policy. Compute returns a schedule given the
+// execution times of tasks on the cores they are mapped to in the reference
+// schedule.
+type policy interface {
+	Compute(*time.Schedule, []float64) *time.Schedule
+}
+
+// retiming is the static policy, which keeps the mapping and order.
+type retiming struct {
+	list *time.List
+}
+
+// dispatcher is a dynamic policy, which maps and orders tasks as they become
+// ready. The execution time of a task on a core other than the one it is
+// mapped to in the reference schedule is scaled according to the platform.
+type dispatcher struct {
+	platform    *system.Platform
+	application *system.Application
+	priority    []float64
+	earliest    bool
+}
+
+// createPolicy creates a scheduling policy. Since the operating points of
+// tasks refer to the cores the tasks are mapped to in the reference schedule,
+// the dynamic policies, which can move tasks to other cores, are not allowed
+// in combination with DVFS.
+func createPolicy(name string, platform *system.Platform, application *system.Application,
+	list *time.List, priority []float64, dvfs *dvfs) (policy, error) {
+
+	switch name {
+	case "", "static":
+		return &retiming{list: list}, nil
+	case "list", "earliest-finish-time":
+		if dvfs != nil {
+			return nil, errors.New(fmt.Sprintf("the scheduling policy “%s” cannot be "+
+				"used with operating points assigned to tasks", name))
+		}
+		return &dispatcher{platform: platform, application: application,
+			priority: priority, earliest: name == "earliest-finish-time"}, nil
+	default:
+		return nil, errors.New("the scheduling policy is unknown")
+	}
+}
+
+func (self *retiming) Compute(reference *time.Schedule, duration []float64) *time.Schedule {
+	return self.list.Update(reference, duration)
+}
+
+func (self *dispatcher) Compute(reference *time.Schedule, duration []float64) *time.Schedule {
+	cores, tasks := self.platform.Cores, self.application.Tasks
+	nc, nt := uint(len(cores)), uint(len(tasks))
+
+	cost := func(i, k uint) float64 {
+		kind, j := tasks[i].Type, reference.Mapping[i]
+		if base := cores[j].Time[kind]; base > 0.0 {
+			return duration[i] * cores[k].Time[kind] / base
+		}
+		return duration[i]
+	}
+
+	schedule := &time.Schedule{
+		Cores:   nc,
+		Tasks:   nt,
+		Mapping: make([]uint, nt),
+		Order:   make([]uint, 0, nt),
+		Start:   make([]float64, nt),
+		Finish:  make([]float64, nt),
+	}
+
+	available := make([]float64, nc)
+	pending := make([]uint, nt)
+	ready := []uint{}
+	for i := uint(0); i < nt; i++ {
+		pending[i] = uint(len(tasks[i].Parents))
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	for len(ready) > 0 {
+		candidates := ready
+		if !self.earliest {
+			best := 0
+			for p := range ready {
+				if self.precedes(ready[p], ready[best]) {
+					best = p
+				}
+			}
+			candidates = ready[best : best+1]
+		}
+
+		position, task, core, start, finish := -1, uint(0), uint(0), 0.0, math.Inf(1)
+		for _, i := range candidates {
+			release := 0.0
+			for _, j := range tasks[i].Parents {
+				release = math.Max(release, schedule.Finish[j])
+			}
+			for k := uint(0); k < nc; k++ {
+				s := math.Max(release, available[k])
+				f := s + cost(i, k)
+				if position < 0 || f < finish || f == finish && self.precedes(i, task) {
+					position, task, core, start, finish = 0, i, k, s, f
+				}
+			}
+		}
+		for p := range ready {
+			if ready[p] == task {
+				position = p
+				break
+			}
+		}
+
+		ready = append(ready[:position], ready[position+1:]...)
+
+		schedule.Mapping[task] = core
+		schedule.Order = append(schedule.Order, task)
+		schedule.Start[task] = start
+		schedule.Finish[task] = finish
+		schedule.Span = math.Max(schedule.Span, finish)
+		available[core] = finish
+
+		for _, j := range tasks[task].Children {
+			pending[j]--
+			if pending[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	return schedule
+}
+
+// precedes checks if a task has a higher priority than another one. Lower
+// values have higher priorities, and ties are broken by the indices.
+func (self *dispatcher) precedes(i, j uint) bool {
+	if self.priority[i] != self.priority[j] {
+		return self.priority[i] < self.priority[j]
+	}
+	return i < j
+}
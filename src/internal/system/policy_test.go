@@ -0,0 +1,46 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/system"
+	"github.com/turing-complete/time"
+)
+
+func TestDispatcher(t *testing.T) {
+	platform := &system.Platform{Cores: []system.Core{
+		{ID: 0, Time: []float64{1.0}},
+		{ID: 1, Time: []float64{1.0}},
+	}}
+	application := &system.Application{Tasks: []system.Task{
+		{ID: 0, Children: []uint{1, 2}},
+		{ID: 1, Parents: []uint{0}},
+		{ID: 2, Parents: []uint{0}},
+	}}
+	reference := &time.Schedule{Mapping: []uint{0, 0, 0}}
+	priority := []float64{0.0, 1.0, 0.5}
+	duration := []float64{1.0, 2.0, 3.0}
+
+	policy, _ := createPolicy("list", platform, application, nil, priority, nil)
+	schedule := policy.Compute(reference, duration)
+	assert.Equal(schedule.Mapping, []uint{0, 1, 0}, t)
+	assert.Equal(schedule.Order, []uint{0, 2, 1}, t)
+	assert.Equal(schedule.Start, []float64{0.0, 1.0, 1.0}, t)
+	assert.Equal(schedule.Finish, []float64{1.0, 3.0, 4.0}, t)
+	assert.Equal(schedule.Span, 4.0, t)
+
+	policy, _ = createPolicy("earliest-finish-time", platform, application, nil, priority, nil)
+	schedule = policy.Compute(reference, duration)
+	assert.Equal(schedule.Mapping, []uint{0, 0, 1}, t)
+	assert.Equal(schedule.Order, []uint{0, 1, 2}, t)
+	assert.Equal(schedule.Start, []float64{0.0, 1.0, 1.0}, t)
+	assert.Equal(schedule.Finish, []float64{1.0, 3.0, 4.0}, t)
+	assert.Equal(schedule.Span, 4.0, t)
+
+	_, err := createPolicy("random", platform, application, nil, priority, nil)
+	assert.Failure(err, t)
+
+	_, err = createPolicy("list", platform, application, nil, priority, &dvfs{})
+	assert.Failure(err, t)
+}
@@ -0,0 +1,130 @@
+package system
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// specification is the part of a TGFF file that is not exposed by the system
+// package, that is, the arcs between tasks, the communication quantities of
+// the arcs, and the hard deadlines of tasks.
+type specification struct {
+	arcs       []arc
+	quantities map[uint]float64
+	deadlines  []float64
+}
+
+type arc struct {
+	from uint
+	to   uint
+	kind uint
+}
+
+func loadSpecification(path string) (*specification, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tasks := make(map[string]uint)
+	deadlines := make(map[uint]float64)
+
+	result := &specification{}
+
+	invalid := func(line string) error {
+		return errors.New(fmt.Sprintf("cannot parse “%s” in the file “%s”", line, path))
+	}
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "@") {
+			section = strings.TrimPrefix(fields[0], "@")
+			continue
+		}
+		if fields[0] == "}" {
+			section = ""
+			continue
+		}
+
+		if section == "COMMUN_QUANT" {
+			if len(fields) < 2 {
+				return nil, invalid(line)
+			}
+			kind, err := strconv.ParseUint(fields[0], 10, 64)
+			if err != nil {
+				return nil, invalid(line)
+			}
+			quantity, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, invalid(line)
+			}
+			if result.quantities == nil {
+				result.quantities = make(map[uint]float64)
+			}
+			result.quantities[uint(kind)] = quantity
+			continue
+		}
+
+		switch fields[0] {
+		case "TASK":
+			if len(fields) < 2 {
+				return nil, invalid(line)
+			}
+			tasks[fields[1]] = uint(len(tasks))
+		case "ARC":
+			from, ok1 := tasks[lookup(fields, "FROM")]
+			to, ok2 := tasks[lookup(fields, "TO")]
+			kind, err := strconv.ParseUint(lookup(fields, "TYPE"), 10, 64)
+			if !ok1 || !ok2 || err != nil {
+				return nil, invalid(line)
+			}
+			result.arcs = append(result.arcs, arc{from: from, to: to, kind: uint(kind)})
+		case "HARD_DEADLINE":
+			tid, ok := tasks[lookup(fields, "ON")]
+			deadline, err := strconv.ParseFloat(lookup(fields, "AT"), 64)
+			if !ok || err != nil {
+				return nil, invalid(line)
+			}
+			if previous, ok := deadlines[tid]; !ok || deadline < previous {
+				deadlines[tid] = deadline
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	result.deadlines = make([]float64, len(tasks))
+	for i := range result.deadlines {
+		result.deadlines[i] = math.Inf(1)
+	}
+	for tid, deadline := range deadlines {
+		result.deadlines[tid] = deadline
+	}
+
+	return result, nil
+}
+
+func lookup(fields []string, key string) string {
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == key {
+			return fields[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,201 @@
+package system
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ready-steady/linear/decomposition"
+	"github.com/turing-complete/hotspot"
+	"github.com/turing-complete/laboratory/src/internal/config"
+
+	temperature "github.com/turing-complete/temperature/analytic"
+)
+
+// thermal is a thermal simulator. ComputeWithStatic returns the temperature
+// profile corresponding to a power profile; the callback is given the index of
+// the time step and the current temperature and power of the cores at that
+// step and should add the static power to the latter.
+type thermal interface {
+	ComputeWithStatic([]float64, func(uint, []float64, []float64)) []float64
+}
+
+// steady is the periodic steady-state simulator. The underlying solver calls
+// back once per time step in order, possibly making several passes over the
+// power profile, which is how the index of the time step is recovered.
+type steady struct {
+	simulator *temperature.Fixed
+	nc        uint
+}
+
+// transient is a thermal simulator that starts from a given initial state. It
+// is based on the same linear model as the steady-state simulator:
+//
+// C dT/dt + G T = P.
+//
+// With X = C^(1/2) T and D = C^(-1/2), the model is discretized as
+//
+// X(k+1) = E X(k) + F P(k)
+//
+// where E = exp(-Δt D G D) and F is the corresponding integral multiplied by D.
+type transient struct {
+	E []float64
+	F []float64
+	D []float64
+
+	X0 []float64
+
+	ambience float64
+
+	nc uint
+	nn uint
+}
+
+// createThermal returns a thermal simulator and the number of iterations of
+// the application that it should be given.
+func createThermal(config *config.System, nc uint) (thermal, uint, error) {
+	switch config.Thermal.Mode {
+	case "", "steady":
+		simulator, err := temperature.NewFixed(&config.Config)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &steady{simulator: simulator, nc: nc}, 1, nil
+	case "transient":
+		simulator, err := newTransient(config)
+		if err != nil {
+			return nil, 0, err
+		}
+		iterations := config.Thermal.Iterations
+		if iterations == 0 {
+			iterations = 1
+		}
+		return simulator, iterations, nil
+	default:
+		return nil, 0, errors.New("the thermal mode is unknown")
+	}
+}
+
+func newTransient(config *config.System) (*transient, error) {
+	model, err := hotspot.New(config.Floorplan, config.Configuration, config.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	return assemble(model, config.TimeStep, config.Ambience, config.Thermal.Initial)
+}
+
+func assemble(model *hotspot.Model, Δt, ambience float64,
+	initial []float64) (*transient, error) {
+
+	nc, nn := model.Cores, model.Nodes
+
+	D := make([]float64, nn)
+	for i := uint(0); i < nn; i++ {
+		D[i] = 1.0 / math.Sqrt(model.C[i])
+	}
+
+	A := make([]float64, nn*nn)
+	for i := uint(0); i < nn; i++ {
+		for j := uint(0); j < nn; j++ {
+			A[j*nn+i] = -D[i] * model.G[j*nn+i] * D[j]
+		}
+	}
+
+	U, Λ := make([]float64, nn*nn), make([]float64, nn)
+	if err := decomposition.SymmetricEigen(A, U, Λ, nn); err != nil {
+		return nil, err
+	}
+
+	e, f := make([]float64, nn), make([]float64, nn)
+	for k := uint(0); k < nn; k++ {
+		e[k] = math.Exp(Δt * Λ[k])
+		f[k] = (e[k] - 1.0) / Λ[k]
+	}
+
+	E, F := make([]float64, nn*nn), make([]float64, nn*nc)
+	for i := uint(0); i < nn; i++ {
+		for j := uint(0); j < nn; j++ {
+			sum := 0.0
+			for k := uint(0); k < nn; k++ {
+				sum += U[k*nn+i] * e[k] * U[k*nn+j]
+			}
+			E[j*nn+i] = sum
+		}
+		for j := uint(0); j < nc; j++ {
+			sum := 0.0
+			for k := uint(0); k < nn; k++ {
+				sum += U[k*nn+i] * f[k] * U[k*nn+j]
+			}
+			F[j*nn+i] = sum * D[j]
+		}
+	}
+
+	if n := uint(len(initial)); n != 0 && n != nc && n != nn {
+		return nil, errors.New("the initial state should have a value for each core or each thermal node")
+	}
+	X0 := make([]float64, nn)
+	for i := range initial {
+		X0[i] = (initial[i] - ambience) / D[i]
+	}
+
+	return &transient{
+		E: E,
+		F: F,
+		D: D,
+
+		X0: X0,
+
+		ambience: ambience,
+
+		nc: nc,
+		nn: nn,
+	}, nil
+}
+
+func (self *steady) ComputeWithStatic(P []float64,
+	update func(uint, []float64, []float64)) []float64 {
+
+	ns, k := uint(len(P))/self.nc, uint(0)
+	return self.simulator.ComputeWithStatic(P, func(Q, P []float64) {
+		update(k%ns, Q, P)
+		k++
+	})
+}
+
+func (self *transient) ComputeWithStatic(P []float64,
+	update func(uint, []float64, []float64)) []float64 {
+
+	nc, nn := self.nc, self.nn
+	ns := uint(len(P)) / nc
+
+	Q := make([]float64, nc*ns)
+
+	X, Y := append([]float64(nil), self.X0...), make([]float64, nn)
+	current := make([]float64, nc)
+	for i := uint(0); i < nc; i++ {
+		current[i] = self.D[i]*X[i] + self.ambience
+	}
+
+	for k := uint(0); k < ns; k++ {
+		p := P[k*nc : (k+1)*nc]
+		update(k, current, p)
+
+		for i := uint(0); i < nn; i++ {
+			sum := 0.0
+			for j := uint(0); j < nn; j++ {
+				sum += self.E[j*nn+i] * X[j]
+			}
+			for j := uint(0); j < nc; j++ {
+				sum += self.F[j*nn+i] * p[j]
+			}
+			Y[i] = sum
+		}
+		X, Y = Y, X
+
+		current = Q[k*nc : (k+1)*nc]
+		for i := uint(0); i < nc; i++ {
+			current[i] = self.D[i]*X[i] + self.ambience
+		}
+	}
+
+	return Q
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/hotspot"
+)
+
+func TestTransient(t *testing.T) {
+	model := &hotspot.Model{Cores: 1, Nodes: 1, C: []float64{2.0}, G: []float64{0.5}}
+
+	transient, err := assemble(model, 0.1, 300.0, []float64{310.0})
+	assert.Success(err, t)
+
+	P, steps := []float64{1.0, 1.0, 0.0}, []uint{}
+	Q := transient.ComputeWithStatic(P, func(k uint, Q, P []float64) {
+		steps = append(steps, k)
+		P[0] += 0.5
+	})
+
+	e, expected := math.Exp(-0.025), make([]float64, 3)
+	for k, T := 0, 10.0; k < 3; k++ {
+		T = e*T + (1.0-e)/0.5*P[k]
+		expected[k] = 300.0 + T
+	}
+
+	assert.Equal(steps, []uint{0, 1, 2}, t)
+	assert.Close(P, []float64{1.5, 1.5, 0.5}, 1e-15, t)
+	assert.Close(Q, expected, 1e-12, t)
+
+	_, err = assemble(model, 0.1, 300.0, []float64{310.0, 320.0})
+	assert.Failure(err, t)
+}
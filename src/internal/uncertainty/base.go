@@ -2,11 +2,10 @@ package uncertainty
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
-	"github.com/ready-steady/infinity"
 	"github.com/ready-steady/linear/matrix"
-	"github.com/ready-steady/probability/distribution"
 	"github.com/ready-steady/statistics/correlation"
 	"github.com/turing-complete/laboratory/src/internal/config"
 	"github.com/turing-complete/laboratory/src/internal/support"
@@ -17,8 +16,7 @@ import (
 )
 
 var (
-	eigenEpsilon     = 1e-10
-	standardGaussian = distribution.NewGaussian(0.0, 1.0)
+	eigenEpsilon = 1e-10
 )
 
 type base struct {
@@ -30,26 +28,8 @@ type base struct {
 	nu uint
 	nz uint
 
-	copula    *copula
-	marginals []distribution.Continuous
-}
-
-// x(z) = F^(-1)(u(z))
-// u(z) = Φ(C * Φ^(-1)(z))
-//
-// z(x) = Φ(D * Φ^(-1)(u(x)))
-// u(x) = F(x)
-//
-// f(u) = exp(-0.5 * Φ^(-1)(u)^T * (R^(-1) - I) * Φ^(-1)(u)) / sqrt(det(R))
-//      = exp(-0.5 * Φ^(-1)(u)^T * P * Φ^(-1)(u)) / N
-//
-// f(x) = prod(f(x)) * f(F(x))
-type copula struct {
-	R []float64
-	C []float64
-	D []float64
-	P []float64
-	N float64
+	copula    copula
+	marginals []idistribution.Continuous
 }
 
 func newBase(system *system.System, reference []float64,
@@ -64,34 +44,23 @@ func newBase(system *system.System, reference []float64,
 
 	nu := uint(len(tasks))
 
-	lower := make([]float64, nt)
-	upper := make([]float64, nt)
-
-	copy(lower, reference)
-	copy(upper, reference)
-
-	for _, tid := range tasks {
-		lower[tid] -= config.Deviation * reference[tid]
-		upper[tid] += config.Deviation * reference[tid]
+	lower, upper, err := bound(config, reference, tasks)
+	if err != nil {
+		return nil, err
 	}
 
-	copula, err := correlate(system, config, tasks)
+	copula, err := newCopula(system, config, tasks)
 	if err != nil {
 		return nil, err
 	}
 
-	nz := uint(len(copula.C)) / nu
+	_, nz := copula.Dimensions()
 
-	marginal, err := idistribution.Parse(config.Distribution)
+	marginals, err := distribute(config, tasks, lower, upper)
 	if err != nil {
 		return nil, err
 	}
 
-	marginals := make([]distribution.Continuous, nu)
-	for i := uint(0); i < nu; i++ {
-		marginals[i] = marginal
-	}
-
 	return &base{
 		tasks: tasks,
 		lower: lower,
@@ -111,30 +80,23 @@ func (self *base) Mapping() (uint, uint) {
 }
 
 func (self *base) Evaluate(ω []float64) float64 {
-	nu, nz := self.nu, self.nz
+	nu := self.nu
 	lower, upper := self.lower, self.upper
 
-	if nu != nz {
-		panic("model-order reduction is not supported")
-	}
-
 	amplitude, u := 1.0, make([]float64, nu)
 	for i, tid := range self.tasks {
 		ω := (ω[tid] - lower[tid]) / (upper[tid] - lower[tid])
-		u[i] = standardGaussian.Invert(self.marginals[i].Cumulate(ω))
+		u[i] = self.marginals[i].Cumulate(ω)
 		amplitude *= self.marginals[i].Weigh(ω)
 	}
 
-	exponent := -0.5 * infinity.Quadratic(self.copula.P, u, nu)
-
-	return amplitude * math.Exp(exponent) / self.copula.N
+	return amplitude * self.copula.Weigh(u)
 }
 
 func (self *base) Forward(ω []float64) []float64 {
-	nu, nz := self.nu, self.nz
+	nu := self.nu
 	lower, upper := self.lower, self.upper
 
-	z := make([]float64, nz)
 	u := make([]float64, nu)
 
 	// Dependent desired to dependent uniform
@@ -143,93 +105,252 @@ func (self *base) Forward(ω []float64) []float64 {
 		u[i] = self.marginals[i].Cumulate(ω)
 	}
 
-	// Dependent uniform to dependent Gaussian
-	for i := range u {
-		u[i] = standardGaussian.Invert(u[i])
-	}
+	// Dependent uniform to independent uniform
+	return self.copula.Forward(u)
+}
 
-	// Dependent Gaussian to independent Gaussian
-	n := infinity.Linear(self.copula.D, u, nz, nu)
+func (self *base) Backward(z []float64) []float64 {
+	lower, upper := self.lower, self.upper
+
+	ω := append([]float64(nil), lower...)
+
+	// Independent uniform to dependent uniform
+	u := self.copula.Backward(z)
 
-	// Independent Gaussian to independent uniform
-	for i := range n {
-		z[i] = standardGaussian.Cumulate(n[i])
+	// Dependent uniform to dependent desired
+	for i, tid := range self.tasks {
+		ω[tid] += (upper[tid] - lower[tid]) * self.marginals[i].Invert(u[i])
 	}
 
-	return z
+	return ω
 }
 
-func (self *base) Backward(z []float64) []float64 {
-	nu, nz := self.nu, self.nz
-	lower, upper := self.lower, self.upper
+func bound(config *config.Uncertainty, reference []float64,
+	tasks []uint) ([]float64, []float64, error) {
 
-	ω := append([]float64(nil), lower...)
-	n := make([]float64, nz)
+	nt := uint(len(reference))
 
-	// Independent uniform to independent Gaussian
-	for i := range n {
-		n[i] = standardGaussian.Invert(z[i])
+	lower := make([]float64, nt)
+	upper := make([]float64, nt)
+
+	copy(lower, reference)
+	copy(upper, reference)
+
+	for _, tid := range tasks {
+		lower[tid] -= config.Deviation * reference[tid]
+		upper[tid] += config.Deviation * reference[tid]
 	}
 
-	// Independent Gaussian to dependent Gaussian
-	u := infinity.Linear(self.copula.C, n, nu, nz)
+	position := locate(tasks, nt)
 
-	// Dependent Gaussian to dependent uniform
-	for i := range u {
-		u[i] = standardGaussian.Cumulate(u[i])
+	for _, group := range config.Deviations {
+		index, err := support.ParseNaturalIndex(group.Tasks, 0, nt-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tid := range index {
+			if position[tid] < 0 {
+				return nil, nil, errors.New(fmt.Sprintf("the task %d is not uncertain", tid))
+			}
+			if group.Lower != nil {
+				lower[tid] = reference[tid] - *group.Lower*reference[tid]
+			}
+			if group.Upper != nil {
+				upper[tid] = reference[tid] + *group.Upper*reference[tid]
+			}
+			if group.Minimum != nil {
+				lower[tid] = *group.Minimum
+			}
+			if group.Maximum != nil {
+				upper[tid] = *group.Maximum
+			}
+		}
 	}
 
-	// Dependent uniform to dependent desired
-	for i, tid := range self.tasks {
-		ω[tid] += (upper[tid] - lower[tid]) * self.marginals[i].Invert(u[i])
+	for _, tid := range tasks {
+		if lower[tid] < 0.0 {
+			return nil, nil, errors.New(fmt.Sprintf("the lower bound of task %d is negative", tid))
+		}
+		if lower[tid] > upper[tid] {
+			return nil, nil, errors.New(fmt.Sprintf("the range of task %d is empty", tid))
+		}
 	}
 
-	return ω
+	return lower, upper, nil
+}
+
+func distribute(config *config.Uncertainty, tasks []uint,
+	lower, upper []float64) ([]idistribution.Continuous, error) {
+
+	nt, nu := uint(len(lower)), uint(len(tasks))
+
+	position := locate(tasks, nt)
+	marginals := make([]idistribution.Continuous, nu)
+
+	assign := func(line string, index []uint) error {
+		ni := uint(len(index))
+		l, u := make([]float64, ni), make([]float64, ni)
+		for i, tid := range index {
+			l[i], u[i] = lower[tid], upper[tid]
+		}
+		distributions, err := idistribution.ParseTasks(line, index, l, u)
+		if err != nil {
+			return err
+		}
+		for i, tid := range index {
+			marginals[position[tid]] = distributions[i]
+		}
+		return nil
+	}
+
+	for _, group := range config.Marginals {
+		index, err := support.ParseNaturalIndex(group.Tasks, 0, nt-1)
+		if err != nil {
+			return nil, err
+		}
+		for _, tid := range index {
+			if position[tid] < 0 {
+				return nil, errors.New(fmt.Sprintf("the task %d is not uncertain", tid))
+			}
+		}
+		if err := assign(group.Distribution, index); err != nil {
+			return nil, err
+		}
+	}
+
+	index := make([]uint, 0, nu)
+	for i, tid := range tasks {
+		if marginals[i] == nil {
+			index = append(index, tid)
+		}
+	}
+	if len(index) > 0 {
+		if err := assign(config.Distribution, index); err != nil {
+			return nil, err
+		}
+	}
+
+	return marginals, nil
+}
+
+func locate(tasks []uint, nt uint) []int {
+	position := make([]int, nt)
+	for i := range position {
+		position[i] = -1
+	}
+	for i, tid := range tasks {
+		position[tid] = i
+	}
+	return position
 }
 
 func correlate(system *system.System, config *config.Uncertainty,
-	tasks []uint) (*copula, error) {
+	tasks []uint) (*elliptical, error) {
 
 	nu := uint(len(tasks))
 
+	if len(config.Matrix) > 0 {
+		R, err := load(system, config, tasks)
+		if err != nil {
+			return nil, err
+		}
+		return decompose(R, config, nu)
+	}
+
 	if config.Correlation == 0.0 {
-		return &copula{
+		return &elliptical{
 			R: matrix.Identity(nu),
 			C: matrix.Identity(nu),
 			D: matrix.Identity(nu),
-			P: make([]float64, nu*nu),
+			Q: matrix.Identity(nu),
 			N: 1.0,
+
+			nu: nu,
+			nz: nu,
 		}, nil
 	}
 	if config.Correlation < 0.0 {
 		return nil, errors.New("the correlation length should be nonnegative")
 	}
+
+	kernel, err := icorrelation.NewKernel(config.Kernel, config.Correlation, config.Shape)
+	if err != nil {
+		return nil, err
+	}
+
+	var volume []float64
+	if config.Metric == "communication" {
+		if volume, err = system.ComputeCommunication(); err != nil {
+			return nil, err
+		}
+	}
+
+	metric, err := icorrelation.NewMetric(config.Metric, system.Application,
+		system.ReferenceSchedule(), volume)
+	if err != nil {
+		return nil, err
+	}
+
+	R := icorrelation.Compute(system.Application, tasks, metric, kernel)
+
+	return decompose(R, config, nu)
+}
+
+func decompose(R []float64, config *config.Uncertainty, nu uint) (*elliptical, error) {
 	if config.Variance <= 0.0 {
 		return nil, errors.New("the variance threshold should be positive")
 	}
 
-	R := icorrelation.Compute(system.Application, tasks, config.Correlation)
-
 	C, D, U, Λ, err := correlation.Decompose(R, nu, config.Variance, eigenEpsilon)
 	if err != nil {
 		return nil, err
 	}
 
-	detR := 1.0
 	for _, λ := range Λ {
 		if λ <= 0.0 {
 			return nil, errors.New("the corelation matrix is invalid or singular")
 		}
-		detR *= λ
 	}
 
-	P, err := invert(U, Λ, nu)
+	nz := uint(len(C)) / nu
+
+	var Q []float64
+	if nz == nu {
+		Q, err = invert(U, Λ, nu)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		Q = project(D, nz, nu)
+	}
+
+	return &elliptical{
+		R: R,
+		C: C,
+		D: D,
+		Q: Q,
+		N: math.Sqrt(multiply(Λ, nz)),
+
+		nu: nu,
+		nz: nz,
+	}, nil
+}
+
+func load(system *system.System, config *config.Uncertainty, tasks []uint) ([]float64, error) {
+	nt, nu := uint(system.Application.Len()), uint(len(tasks))
+
+	R, n, err := icorrelation.Load(config.Matrix)
 	if err != nil {
 		return nil, err
 	}
-	for i := uint(0); i < nu; i++ {
-		P[i*nu+i] -= 1.0
+
+	switch n {
+	case nt:
+		R = icorrelation.Select(R, n, tasks)
+	case nu:
+	default:
+		return nil, errors.New(fmt.Sprintf("the correlation matrix should be of size %d or %d", nt, nu))
 	}
 
-	return &copula{R: R, C: C, D: D, P: P, N: math.Sqrt(detR)}, nil
+	return icorrelation.Validate(R, nu, config.Repair)
 }
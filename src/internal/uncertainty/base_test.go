@@ -5,6 +5,9 @@ import (
 
 	"github.com/ready-steady/assert"
 	"github.com/ready-steady/probability/distribution"
+	"github.com/turing-complete/laboratory/src/internal/config"
+
+	idistribution "github.com/turing-complete/laboratory/src/internal/distribution"
 )
 
 func TestBaseForwardInvert(t *testing.T) {
@@ -17,7 +20,7 @@ func TestBaseForwardInvert(t *testing.T) {
 		nu: 3,
 		nz: 2,
 
-		copula: &copula{
+		copula: &gaussianCopula{elliptical{
 			C: []float64{
 				1.0, 2.0, 3.0,
 				4.0, 5.0, 6.0,
@@ -27,8 +30,11 @@ func TestBaseForwardInvert(t *testing.T) {
 				4.0, 3.0,
 				2.0, 1.0,
 			},
-		},
-		marginals: []distribution.Continuous{
+
+			nu: 3,
+			nz: 2,
+		}},
+		marginals: []idistribution.Continuous{
 			distribution.NewUniform(0.0, 1.0),
 			distribution.NewUniform(0.0, 1.0),
 			distribution.NewUniform(0.0, 1.0),
@@ -48,3 +54,95 @@ func TestBaseForwardInvert(t *testing.T) {
 		3.973501094321997e+01,
 	}, 1e-14, t)
 }
+
+func TestBaseEvaluateReduced(t *testing.T) {
+	R := []float64{
+		1.0, 0.8,
+		0.8, 1.0,
+	}
+	elliptical, err := decompose(R, &config.Uncertainty{Variance: 0.85}, 2)
+	assert.Success(err, t)
+	assert.Equal(elliptical.nz, uint(1), t)
+
+	uncertainty := &base{
+		tasks: []uint{0, 1},
+		lower: []float64{10.0, 20.0},
+		upper: []float64{20.0, 30.0},
+
+		nt: 2,
+		nu: 2,
+		nz: 1,
+
+		copula: &gaussianCopula{*elliptical},
+		marginals: []idistribution.Continuous{
+			distribution.NewUniform(0.0, 1.0),
+			distribution.NewUniform(0.0, 1.0),
+		},
+	}
+
+	value := uncertainty.Evaluate([]float64{13.0, 26.0})
+	assert.Close(value, 2.191160620426873e+00, 1e-14, t)
+}
+
+func TestDistribute(t *testing.T) {
+	config := &config.Uncertainty{
+		Distribution: "Uniform()",
+		Marginals: []config.Marginal{
+			{Tasks: "[1, 3]", Distribution: "Beta(2, 5)"},
+			{Tasks: "[3]", Distribution: "Beta(1, 1)"},
+		},
+	}
+
+	lower := []float64{0.0, 0.0, 0.0, 0.0}
+	upper := []float64{1.0, 1.0, 1.0, 1.0}
+
+	marginals, err := distribute(config, []uint{0, 1, 3}, lower, upper)
+	assert.Success(err, t)
+	assert.Equal(len(marginals), 3, t)
+
+	_, ok := marginals[0].(*distribution.Uniform)
+	assert.Equal(ok, true, t)
+	_, ok = marginals[1].(*distribution.Beta)
+	assert.Equal(ok, true, t)
+	_, ok = marginals[2].(*distribution.Beta)
+	assert.Equal(ok, true, t)
+	assert.Equal(marginals[1] == marginals[2], false, t)
+
+	config.Marginals[1].Tasks = "[2]"
+	_, err = distribute(config, []uint{0, 1, 3}, lower, upper)
+	assert.Failure(err, t)
+}
+
+func TestBound(t *testing.T) {
+	zero, half := 0.0, 0.5
+	minimum, maximum := 15.0, 25.0
+
+	config := &config.Uncertainty{
+		Deviation: 0.2,
+		Deviations: []config.Deviation{
+			{Tasks: "[1:2]", Lower: &zero, Upper: &half},
+			{Tasks: "[2]", Maximum: &maximum},
+			{Tasks: "[3]", Minimum: &minimum, Maximum: &maximum},
+		},
+	}
+	reference := []float64{10.0, 10.0, 20.0, 20.0, 10.0}
+
+	lower, upper, err := bound(config, reference, []uint{0, 1, 2, 3})
+	assert.Success(err, t)
+	assert.Close(lower, []float64{8.0, 10.0, 20.0, 15.0, 10.0}, 1e-15, t)
+	assert.Close(upper, []float64{12.0, 15.0, 25.0, 25.0, 10.0}, 1e-15, t)
+
+	config.Deviations[0].Upper = &zero
+	lower, upper, err = bound(config, reference, []uint{0, 1, 2, 3})
+	assert.Success(err, t)
+	assert.Equal(lower[1], upper[1], t)
+
+	config.Deviations[1].Maximum = &minimum
+	_, _, err = bound(config, reference, []uint{0, 1, 2, 3})
+	assert.Failure(err, t)
+
+	config.Deviations = config.Deviations[2:]
+	config.Deviations[0].Tasks = "[4]"
+	_, _, err = bound(config, reference, []uint{0, 1, 2, 3})
+	assert.Failure(err, t)
+}
@@ -0,0 +1,416 @@
+package uncertainty
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ready-steady/infinity"
+	"github.com/ready-steady/probability/distribution"
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/system"
+
+	idistribution "github.com/turing-complete/laboratory/src/internal/distribution"
+)
+
+var (
+	copulaPattern    = regexp.MustCompile(`^\s*([^(]*?)\s*\((.*)\)\s*$`)
+	standardGaussian = distribution.NewGaussian(0.0, 1.0)
+)
+
+// copula is the dependence structure of the uncertain parameters. Forward maps
+// dependent uniform variables to independent ones, Backward does the opposite,
+// and Weigh evaluates the density of the dependent variables.
+type copula interface {
+	Dimensions() (uint, uint)
+
+	Forward([]float64) []float64
+	Backward([]float64) []float64
+	Weigh([]float64) float64
+}
+
+// elliptical is the correlation structure of an elliptical copula.
+//
+// R = C * C^T
+// I = D * C
+//
+// Q = R^(-1)
+// N = sqrt(det(R))
+//
+// In the case of model-order reduction, R^(-1) and det(R) are replaced with
+// D^T * D and the product of the preserved eigenvalues of R, respectively.
+type elliptical struct {
+	R []float64
+	C []float64
+	D []float64
+	Q []float64
+	N float64
+
+	nu uint
+	nz uint
+}
+
+// x(z) = F^(-1)(u(z))
+// u(z) = Φ(C * Φ^(-1)(z))
+//
+// z(x) = Φ(D * Φ^(-1)(u(x)))
+// u(x) = F(x)
+//
+// f(u) = exp(-0.5 * Φ^(-1)(u)^T * (R^(-1) - I) * Φ^(-1)(u)) / sqrt(det(R))
+// f(u) = exp(-0.5 * Φ^(-1)(u)^T * (Q - I) * Φ^(-1)(u)) / N
+//
+// f(x) = prod(f(x)) * f(F(x))
+//
+// In the case of model-order reduction, the density is the one of the
+// nz-dimensional Gaussian distribution on the subspace spanned by C, which
+// results in an additional factor of (2π)^((nu - nz) / 2).
+type gaussianCopula struct {
+	elliptical
+}
+
+// y(z) = (T_(ν)^(-1)(z_1), ..., T_(ν+k)^(-1)(z_(k+1)) * s_k, ...)
+// u(z) = T_ν(C * y(z))
+//
+// s_k = sqrt((ν + y_1^2 + ... + y_k^2) / (ν + k))
+//
+// f(u) = t_(ν, R)(T_ν^(-1)(u)) / prod(t_ν(T_ν^(-1)(u)))
+//
+// where T_ν and t_ν are the distribution and density functions of Student’s t
+// distribution with ν degrees of freedom, and t_(ν, R) is the density of the
+// corresponding multivariate distribution with the scale matrix R. The mapping
+// between z and y is the Rosenblatt transformation of the multivariate
+// distribution with the identity scale matrix.
+type studentCopula struct {
+	elliptical
+
+	ν float64
+
+	marginal     *student
+	conditionals []*student
+}
+
+// u(z) is the inverse of the Rosenblatt transformation given by
+//
+// z_k = ((1 + s_k) / (1 + s_(k-1)))^(-1/θ - k + 1)
+// s_k = u_1^(-θ) + ... + u_k^(-θ) - k
+//
+// f(u) = prod(1 + (k - 1) * θ) * prod(u_k^(-θ - 1)) * (1 + s_nu)^(-nu - 1/θ)
+type claytonCopula struct {
+	θ  float64
+	nu uint
+}
+
+// student is Student’s t distribution with ν degrees of freedom.
+type student struct {
+	ν    float64
+	beta idistribution.Continuous
+	norm float64
+}
+
+func newCopula(system *system.System, config *config.Uncertainty,
+	tasks []uint) (copula, error) {
+
+	name, params, err := parseCopula(config.Copula)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "clayton" {
+		if config.Correlation != 0.0 || len(config.Kernel) > 0 || len(config.Metric) > 0 ||
+			len(config.Matrix) > 0 || config.Variance != 0.0 {
+
+			return nil, errors.New("the Clayton copula does not accept the correlation settings")
+		}
+		return &claytonCopula{θ: params[0], nu: uint(len(tasks))}, nil
+	}
+
+	elliptical, err := correlate(system, config, tasks)
+	if err != nil {
+		return nil, err
+	}
+	if elliptical.nz == 0 {
+		return nil, errors.New("the copula should preserve at least one dimension")
+	}
+
+	switch name {
+	case "gaussian":
+		return &gaussianCopula{*elliptical}, nil
+	case "student":
+		return newStudentCopula(elliptical, params[0]), nil
+	}
+
+	panic("unreachable")
+}
+
+func parseCopula(line string) (string, []float64, error) {
+	if len(strings.TrimSpace(line)) == 0 {
+		return "gaussian", nil, nil
+	}
+
+	invalid := errors.New(fmt.Sprintf("the copula “%s” is unknown or invalid; the accepted "+
+		"forms are Gaussian(), Student(ν > 0), and Clayton(θ > 0)", line))
+
+	match := copulaPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", nil, invalid
+	}
+
+	name, rest := strings.ToLower(match[1]), strings.TrimSpace(match[2])
+
+	params := make([]float64, 0)
+	if len(rest) > 0 {
+		for _, chunk := range strings.Split(rest, ",") {
+			value, err := strconv.ParseFloat(strings.TrimSpace(chunk), 64)
+			if err != nil {
+				return "", nil, invalid
+			}
+			params = append(params, value)
+		}
+	}
+
+	switch name {
+	case "gaussian":
+		if len(params) == 0 {
+			return name, params, nil
+		}
+	case "student", "clayton":
+		if len(params) == 1 && params[0] > 0.0 {
+			return name, params, nil
+		}
+	}
+
+	return "", nil, invalid
+}
+
+func (self *elliptical) Dimensions() (uint, uint) {
+	return self.nu, self.nz
+}
+
+func (self *gaussianCopula) Forward(u []float64) []float64 {
+	nu, nz := self.nu, self.nz
+
+	g := make([]float64, nu)
+	z := make([]float64, nz)
+
+	// Dependent uniform to dependent Gaussian
+	for i := range u {
+		g[i] = standardGaussian.Invert(u[i])
+	}
+
+	// Dependent Gaussian to independent Gaussian
+	n := infinity.Linear(self.D, g, nz, nu)
+
+	// Independent Gaussian to independent uniform
+	for i := range n {
+		z[i] = standardGaussian.Cumulate(n[i])
+	}
+
+	return z
+}
+
+func (self *gaussianCopula) Backward(z []float64) []float64 {
+	nu, nz := self.nu, self.nz
+
+	n := make([]float64, nz)
+
+	// Independent uniform to independent Gaussian
+	for i := range n {
+		n[i] = standardGaussian.Invert(z[i])
+	}
+
+	// Independent Gaussian to dependent Gaussian
+	u := infinity.Linear(self.C, n, nu, nz)
+
+	// Dependent Gaussian to dependent uniform
+	for i := range u {
+		u[i] = standardGaussian.Cumulate(u[i])
+	}
+
+	return u
+}
+
+func (self *gaussianCopula) Weigh(u []float64) float64 {
+	nu, nz := self.nu, self.nz
+
+	g, norm := make([]float64, nu), 0.0
+	for i := range u {
+		g[i] = standardGaussian.Invert(u[i])
+		norm += g[i] * g[i]
+	}
+
+	exponent := -0.5 * (infinity.Quadratic(self.Q, g, nu) - norm)
+	if nu != nz {
+		exponent += 0.5 * float64(nu-nz) * math.Log(2.0*math.Pi)
+	}
+
+	return math.Exp(exponent) / self.N
+}
+
+func newStudentCopula(elliptical *elliptical, ν float64) *studentCopula {
+	conditionals := make([]*student, elliptical.nz)
+	for i := range conditionals {
+		conditionals[i] = newStudent(ν + float64(i))
+	}
+	return &studentCopula{
+		elliptical: *elliptical,
+
+		ν: ν,
+
+		marginal:     conditionals[0],
+		conditionals: conditionals,
+	}
+}
+
+func (self *studentCopula) Forward(u []float64) []float64 {
+	nu, nz := self.nu, self.nz
+	ν := self.ν
+
+	g := make([]float64, nu)
+	z := make([]float64, nz)
+
+	// Dependent uniform to dependent Student
+	for i := range u {
+		g[i] = self.marginal.Invert(u[i])
+	}
+
+	// Dependent Student to uncorrelated Student
+	y := infinity.Linear(self.D, g, nz, nu)
+
+	// Uncorrelated Student to independent uniform
+	s := ν
+	for k := range y {
+		z[k] = self.conditionals[k].Cumulate(y[k] * math.Sqrt((ν+float64(k))/s))
+		s += y[k] * y[k]
+	}
+
+	return z
+}
+
+func (self *studentCopula) Backward(z []float64) []float64 {
+	nu, nz := self.nu, self.nz
+	ν := self.ν
+
+	y := make([]float64, nz)
+
+	// Independent uniform to uncorrelated Student
+	s := ν
+	for k := range y {
+		y[k] = self.conditionals[k].Invert(z[k]) * math.Sqrt(s/(ν+float64(k)))
+		s += y[k] * y[k]
+	}
+
+	// Uncorrelated Student to dependent Student
+	u := infinity.Linear(self.C, y, nu, nz)
+
+	// Dependent Student to dependent uniform
+	for i := range u {
+		u[i] = self.marginal.Cumulate(u[i])
+	}
+
+	return u
+}
+
+func (self *studentCopula) Weigh(u []float64) float64 {
+	nu, nz := self.nu, self.nz
+	ν, d := self.ν, float64(nz)
+
+	g := make([]float64, nu)
+	for i := range u {
+		g[i] = self.marginal.Invert(u[i])
+	}
+
+	a, _ := math.Lgamma(0.5 * (ν + d))
+	b, _ := math.Lgamma(0.5 * ν)
+
+	exponent := a - b - 0.5*d*math.Log(ν*math.Pi) - math.Log(self.N) -
+		0.5*(ν+d)*math.Log1p(infinity.Quadratic(self.Q, g, nu)/ν)
+	for i := range g {
+		exponent -= self.marginal.logWeigh(g[i])
+	}
+
+	return math.Exp(exponent)
+}
+
+func (self *claytonCopula) Dimensions() (uint, uint) {
+	return self.nu, self.nu
+}
+
+func (self *claytonCopula) Forward(u []float64) []float64 {
+	θ := self.θ
+
+	z := make([]float64, self.nu)
+
+	s := 0.0
+	for k := range u {
+		t := s + math.Pow(u[k], -θ) - 1.0
+		z[k] = math.Pow((1.0+t)/(1.0+s), -1.0/θ-float64(k))
+		s = t
+	}
+
+	return z
+}
+
+func (self *claytonCopula) Backward(z []float64) []float64 {
+	θ := self.θ
+
+	u := make([]float64, self.nu)
+
+	s := 0.0
+	for k := range z {
+		t := (1.0+s)*math.Pow(z[k], -1.0/(1.0/θ+float64(k))) - 1.0
+		u[k] = math.Pow(1.0+t-s, -1.0/θ)
+		s = t
+	}
+
+	return u
+}
+
+func (self *claytonCopula) Weigh(u []float64) float64 {
+	θ, d := self.θ, float64(self.nu)
+
+	exponent, s := 0.0, 0.0
+	for k := range u {
+		exponent += math.Log1p(float64(k)*θ) - (θ+1.0)*math.Log(u[k])
+		s += math.Pow(u[k], -θ) - 1.0
+	}
+	exponent -= (d + 1.0/θ) * math.Log1p(s)
+
+	return math.Exp(exponent)
+}
+
+func newStudent(ν float64) *student {
+	a, _ := math.Lgamma(0.5 * (ν + 1.0))
+	b, _ := math.Lgamma(0.5 * ν)
+	return &student{
+		ν:    ν,
+		beta: distribution.NewBeta(0.5*ν, 0.5, 0.0, 1.0),
+		norm: a - b - 0.5*math.Log(ν*math.Pi),
+	}
+}
+
+func (self *student) Cumulate(t float64) float64 {
+	p := 0.5 * self.beta.Cumulate(self.ν/(self.ν+t*t))
+	if t > 0.0 {
+		return 1.0 - p
+	}
+	return p
+}
+
+func (self *student) Invert(p float64) float64 {
+	if p == 0.5 {
+		return 0.0
+	}
+	x := self.beta.Invert(2.0 * math.Min(p, 1.0-p))
+	t := math.Sqrt(self.ν * (1.0 - x) / x)
+	if p < 0.5 {
+		return -t
+	}
+	return t
+}
+
+func (self *student) logWeigh(t float64) float64 {
+	return self.norm - 0.5*(self.ν+1.0)*math.Log1p(t*t/self.ν)
+}
@@ -0,0 +1,79 @@
+package uncertainty
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestNewCopula(t *testing.T) {
+	_, err := newCopula(nil, &config.Uncertainty{Copula: "Clayton(2)"}, []uint{0, 1})
+	assert.Success(err, t)
+
+	_, err = newCopula(nil, &config.Uncertainty{Copula: "Clayton(2)", Variance: 0.9},
+		[]uint{0, 1})
+	assert.Failure(err, t)
+
+	_, err = newCopula(nil, &config.Uncertainty{Copula: "Student(4)"}, []uint{})
+	assert.Failure(err, t)
+}
+
+func TestParseCopula(t *testing.T) {
+	cases := []struct {
+		line    string
+		name    string
+		success bool
+	}{
+		{"", "gaussian", true},
+		{"Gaussian()", "gaussian", true},
+		{"Student(4)", "student", true},
+		{" student ( 0.5 ) ", "student", true},
+		{"Clayton(2)", "clayton", true},
+		{"Gaussian(1)", "", false},
+		{"Student()", "", false},
+		{"Student(0)", "", false},
+		{"Clayton(-1)", "", false},
+		{"Gumbel(2)", "", false},
+	}
+
+	for _, c := range cases {
+		name, _, err := parseCopula(c.line)
+		if c.success {
+			assert.Success(err, t)
+		} else {
+			assert.Failure(err, t)
+		}
+		assert.Equal(name, c.name, t)
+	}
+}
+
+func TestClaytonCopula(t *testing.T) {
+	copula := &claytonCopula{θ: 2.0, nu: 2}
+
+	z := copula.Forward([]float64{0.3, 0.6})
+	assert.Close(z, []float64{0.3, 8.004109404183268e-01}, 1e-14, t)
+	assert.Close(copula.Backward(z), []float64{0.3, 0.6}, 1e-14, t)
+	assert.Close(copula.Weigh([]float64{0.3, 0.6}), 8.625117892438865e-01, 1e-14, t)
+
+	copula = &claytonCopula{θ: 0.5, nu: 4}
+
+	u := []float64{0.1, 0.4, 0.7, 0.9}
+	assert.Close(copula.Backward(copula.Forward(u)), u, 1e-14, t)
+}
+
+func TestStudentCopula(t *testing.T) {
+	copula := newStudentCopula(&elliptical{
+		C: []float64{1.0, 0.0, 0.0, 1.0},
+		D: []float64{1.0, 0.0, 0.0, 1.0},
+		Q: []float64{1.0, 0.0, 0.0, 1.0},
+		N: 1.0,
+
+		nu: 2,
+		nz: 2,
+	}, 4.0)
+
+	z := []float64{0.2, 0.7}
+	assert.Close(copula.Forward(copula.Backward(z)), z, 1e-12, t)
+	assert.Close(copula.Weigh([]float64{0.5, 0.5}), 1.1317684842090323e+00, 1e-14, t)
+}
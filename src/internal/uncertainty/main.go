@@ -14,11 +14,22 @@ type Uncertainty interface {
 }
 
 func NewAleatory(system *system.System, config *config.Uncertainty) (Uncertainty, error) {
-	return newBase(system, system.ReferenceTime(), config)
+	base, err := newBase(system, system.ReferenceTime(), config)
+	if err != nil {
+		return nil, err
+	}
+	return extend(system, base, config)
 }
 
 func NewEpistemic(system *system.System, config *config.Uncertainty) (Uncertainty, error) {
 	clone := *config
 	clone.Distribution, clone.Correlation, clone.Variance = "Uniform()", 0.0, 1.0
-	return newBase(system, system.ReferenceTime(), &clone)
+	clone.Marginals, clone.Matrix, clone.Copula = nil, "", ""
+	clone.DynamicPower.Distribution = "Uniform()"
+	clone.StaticPower.Distribution = "Uniform()"
+	base, err := newBase(system, system.ReferenceTime(), &clone)
+	if err != nil {
+		return nil, err
+	}
+	return extend(system, base, &clone)
 }
@@ -2,6 +2,7 @@ package uncertainty
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/ready-steady/linear/matrix"
 )
@@ -23,3 +24,30 @@ func invert(U, Λ []float64, m uint) ([]float64, error) {
 
 	return I, nil
 }
+
+func multiply(Λ []float64, m uint) float64 {
+	Λ = append([]float64(nil), Λ...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(Λ)))
+
+	product := 1.0
+	for i := uint(0); i < m; i++ {
+		product *= Λ[i]
+	}
+
+	return product
+}
+
+func project(D []float64, m, n uint) []float64 {
+	P := make([]float64, n*n)
+	for i := uint(0); i < n; i++ {
+		for j := i; j < n; j++ {
+			Σ := 0.0
+			for k := uint(0); k < m; k++ {
+				Σ += D[i*m+k] * D[j*m+k]
+			}
+			P[j*n+i] = Σ
+			P[i*n+j] = Σ
+		}
+	}
+	return P
+}
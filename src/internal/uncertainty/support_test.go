@@ -29,3 +29,26 @@ func TestInverse(t *testing.T) {
 	assert.Equal(err, nil, t)
 	assert.Close(A, I, 1e-14, t)
 }
+
+func TestMultiply(t *testing.T) {
+	Λ := []float64{2.0, 5.0, 0.5, 3.0}
+
+	assert.Equal(multiply(Λ, 0), 1.0, t)
+	assert.Equal(multiply(Λ, 2), 15.0, t)
+	assert.Equal(multiply(Λ, 4), 15.0, t)
+	assert.Equal(Λ, []float64{2.0, 5.0, 0.5, 3.0}, t)
+}
+
+func TestProject(t *testing.T) {
+	D := []float64{
+		1.0, 2.0,
+		3.0, 4.0,
+		5.0, 6.0,
+	}
+
+	assert.Equal(project(D, 2, 3), []float64{
+		5.0, 11.0, 17.0,
+		11.0, 25.0, 39.0,
+		17.0, 39.0, 61.0,
+	}, t)
+}
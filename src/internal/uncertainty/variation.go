@@ -0,0 +1,150 @@
+package uncertainty
+
+import (
+	"errors"
+
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/support"
+	"github.com/turing-complete/laboratory/src/internal/system"
+
+	idistribution "github.com/turing-complete/laboratory/src/internal/distribution"
+)
+
+// extension augments the execution times of tasks with the multipliers of the
+// dynamic power of tasks and the multipliers of the static power of cores:
+//
+// ω = (execution times, dynamic multipliers, static multipliers).
+//
+// The multipliers are independent of each other and of the execution times.
+type extension struct {
+	*base
+
+	dynamic *variation
+	static  *variation
+
+	nz uint
+	no uint
+}
+
+type variation struct {
+	index []uint
+	lower float64
+	upper float64
+
+	marginal idistribution.Continuous
+}
+
+func extend(system *system.System, base *base, config *config.Uncertainty) (Uncertainty, error) {
+	if len(config.DynamicPower.Index) == 0 && len(config.StaticPower.Index) == 0 {
+		return base, nil
+	}
+
+	nt, nc := uint(system.Application.Len()), uint(system.Platform.Len())
+
+	dynamic, err := newVariation(&config.DynamicPower, nt)
+	if err != nil {
+		return nil, err
+	}
+	static, err := newVariation(&config.StaticPower, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extension{
+		base: base,
+
+		dynamic: dynamic,
+		static:  static,
+
+		nz: base.nz + uint(len(dynamic.index)) + uint(len(static.index)),
+		no: base.nt + nt + nc,
+	}, nil
+}
+
+func newVariation(config *config.Variation, n uint) (*variation, error) {
+	if len(config.Index) == 0 {
+		return &variation{}, nil
+	}
+
+	index, err := support.ParseNaturalIndex(config.Index, 0, n-1)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Deviation <= 0.0 || config.Deviation >= 1.0 {
+		return nil, errors.New("the deviation of multipliers should be in (0, 1)")
+	}
+
+	marginal, err := idistribution.Parse(config.Distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variation{
+		index: index,
+		lower: 1.0 - config.Deviation,
+		upper: 1.0 + config.Deviation,
+
+		marginal: marginal,
+	}, nil
+}
+
+func (self *extension) Mapping() (uint, uint) {
+	return self.nz, self.no
+}
+
+func (self *extension) Evaluate(ω []float64) float64 {
+	nt := self.base.nt
+	return self.base.Evaluate(ω[:nt]) *
+		self.dynamic.evaluate(ω[nt:2*nt]) * self.static.evaluate(ω[2*nt:])
+}
+
+func (self *extension) Forward(ω []float64) []float64 {
+	nt := self.base.nt
+
+	z := make([]float64, 0, self.nz)
+	z = append(z, self.base.Forward(ω[:nt])...)
+	z = append(z, self.dynamic.forward(ω[nt:2*nt])...)
+	z = append(z, self.static.forward(ω[2*nt:])...)
+
+	return z
+}
+
+func (self *extension) Backward(z []float64) []float64 {
+	nz, nd := self.base.nz, uint(len(self.dynamic.index))
+	nt, nc := self.base.nt, self.no-2*self.base.nt
+
+	ω := make([]float64, 0, self.no)
+	ω = append(ω, self.base.Backward(z[:nz])...)
+	ω = append(ω, self.dynamic.backward(z[nz:nz+nd], nt)...)
+	ω = append(ω, self.static.backward(z[nz+nd:], nc)...)
+
+	return ω
+}
+
+func (self *variation) evaluate(ω []float64) float64 {
+	density := 1.0
+	for _, i := range self.index {
+		density *= self.marginal.Weigh((ω[i] - self.lower) / (self.upper - self.lower))
+	}
+	return density
+}
+
+func (self *variation) forward(ω []float64) []float64 {
+	z := make([]float64, len(self.index))
+	for j, i := range self.index {
+		z[j] = self.marginal.Cumulate((ω[i] - self.lower) / (self.upper - self.lower))
+	}
+	return z
+}
+
+func (self *variation) backward(z []float64, n uint) []float64 {
+	ω := make([]float64, n)
+	for i := range ω {
+		ω[i] = 1.0
+	}
+	for j, i := range self.index {
+		ω[i] = self.lower + (self.upper-self.lower)*self.marginal.Invert(z[j])
+	}
+	return ω
+}
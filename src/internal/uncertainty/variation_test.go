@@ -0,0 +1,44 @@
+package uncertainty
+
+import (
+	"testing"
+
+	"github.com/ready-steady/assert"
+	"github.com/turing-complete/laboratory/src/internal/config"
+)
+
+func TestNewVariation(t *testing.T) {
+	cases := []struct {
+		config  config.Variation
+		success bool
+	}{
+		{config.Variation{}, true},
+		{config.Variation{Index: "[0, 2]", Distribution: "Triangular(0.5)", Deviation: 0.2}, true},
+		{config.Variation{Index: "[0, 4]", Distribution: "Triangular(0.5)", Deviation: 0.2}, false},
+		{config.Variation{Index: "[0, 2]", Distribution: "Triangular(0.5)", Deviation: 0.0}, false},
+		{config.Variation{Index: "[0, 2]", Distribution: "Triangular(0.5)", Deviation: 1.0}, false},
+	}
+
+	for _, c := range cases {
+		_, err := newVariation(&c.config, 3)
+		if c.success {
+			assert.Success(err, t)
+		} else {
+			assert.Failure(err, t)
+		}
+	}
+}
+
+func TestVariationBackward(t *testing.T) {
+	variation, err := newVariation(&config.Variation{
+		Index:        "[0, 2]",
+		Distribution: "Triangular(0.5)",
+		Deviation:    0.2,
+	}, 3)
+	assert.Success(err, t)
+
+	ω := variation.backward([]float64{0.5, 0.125}, 3)
+	assert.Close(ω, []float64{1.0, 1.0, 0.9}, 1e-14, t)
+	assert.Close(variation.forward(ω), []float64{0.5, 0.125}, 1e-14, t)
+	assert.Close(variation.evaluate(ω), 2.0, 1e-14, t)
+}
@@ -72,7 +72,10 @@ func function(config *config.Config) error {
 	ni, no := aquantity.Dimensions()
 	ns := config.Assessment.Samples
 
-	points := support.Generate(ni, ns, config.Assessment.Seed)
+	points, err := support.Sample(config.Assessment.Sampler, ni, ns, config.Assessment.Seed)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("Evaluating the original model at %d points...\n", ns)
 	values := quantity.Invoke(aquantity, points)
@@ -109,7 +109,10 @@ func function(config *config.Config) error {
 		return err
 	}
 
-	points := generate(target, proxy, ns, config.Assessment.Seed)
+	points, err := generate(target, proxy, ns, &config.Assessment)
+	if err != nil {
+		return err
+	}
 
 	log.Printf("Evaluating the surrogate model at %d points...\n", ns)
 	log.Printf("%5s %15s\n", "Step", "Nodes")
@@ -161,13 +164,18 @@ func function(config *config.Config) error {
 	return nil
 }
 
-func generate(into, from quantity.Quantity, ns uint, seed int64) []float64 {
+func generate(into, from quantity.Quantity, ns uint,
+	config *config.Assessment) ([]float64, error) {
+
 	ni, _ := into.Dimensions()
 	nf, _ := from.Dimensions()
 	zi := make([]float64, ni*ns)
-	zf := support.Generate(nf, ns, seed)
+	zf, err := support.Sample(config.Sampler, nf, ns, config.Seed)
+	if err != nil {
+		return nil, err
+	}
 	for i := uint(0); i < ns; i++ {
 		copy(zi[i*ni:(i+1)*ni], into.Forward(from.Backward(zf[i*nf:(i+1)*nf])))
 	}
-	return zi
+	return zi, nil
 }
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"strconv"
+
+	"github.com/turing-complete/laboratory/src/internal/command"
+	"github.com/turing-complete/laboratory/src/internal/config"
+	"github.com/turing-complete/laboratory/src/internal/database"
+	"github.com/turing-complete/laboratory/src/internal/quantity"
+	"github.com/turing-complete/laboratory/src/internal/sensitivity"
+	"github.com/turing-complete/laboratory/src/internal/solution"
+	"github.com/turing-complete/laboratory/src/internal/system"
+	"github.com/turing-complete/laboratory/src/internal/uncertainty"
+)
+
+var (
+	approximateFile = flag.String("approximate", "", "an output of `approximate` (optional)")
+	outputFile      = flag.String("o", "", "an output file (required)")
+	sampleSeed      = flag.String("s", "", "a seed for generating samples")
+	sampleCount     = flag.String("n", "", "the number of samples")
+	bootstrapCount  = flag.Uint("b", 100, "the number of bootstrap resamples")
+	confidenceLevel = flag.Float64("l", 0.95, "the confidence level")
+)
+
+func main() {
+	command.Run(function)
+}
+
+// The indices are computed with respect to the independent variables of the
+// aleatory probability model. The model is either the quantity of interest
+// itself or, if given, a surrogate constructed by `approximate`.
+func function(config *config.Config) error {
+	if len(*sampleSeed) > 0 {
+		if number, err := strconv.ParseInt(*sampleSeed, 0, 64); err != nil {
+			return err
+		} else {
+			config.Assessment.Seed = number
+		}
+	}
+	if len(*sampleCount) > 0 {
+		if number, err := strconv.ParseUint(*sampleCount, 0, 64); err != nil {
+			return err
+		} else {
+			config.Assessment.Samples = uint(number)
+		}
+	}
+
+	if config.Assessment.Samples == 0 {
+		return errors.New("the number of samples should be positive")
+	}
+
+	output, err := database.Create(*outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	system, err := system.New(&config.System)
+	if err != nil {
+		return err
+	}
+
+	auncertainty, err := uncertainty.NewAleatory(system, &config.Uncertainty)
+	if err != nil {
+		return err
+	}
+	aquantity, err := quantity.New(system, auncertainty, &config.Quantity)
+	if err != nil {
+		return err
+	}
+
+	ni, no := aquantity.Dimensions()
+	ns := config.Assessment.Samples
+
+	points, err := sensitivity.Generate(config.Assessment.Sampler, ni, ns,
+		config.Assessment.Seed)
+	if err != nil {
+		return err
+	}
+
+	var values []float64
+	if len(*approximateFile) == 0 {
+		log.Printf("Evaluating the original model at %d points...\n", (ni+2)*ns)
+		values = quantity.Invoke(aquantity, points)
+	} else {
+		values, err = evaluate(config, system, aquantity, points)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Estimating the sensitivity indices with %d bootstrap resamples...\n",
+		*bootstrapCount)
+
+	indices, err := sensitivity.Estimate(values, ni, no, ns, *bootstrapCount,
+		*confidenceLevel, config.Assessment.Seed)
+	if err != nil {
+		return err
+	}
+
+	if err := output.Put("points", points, ni, (ni+2)*ns); err != nil {
+		return err
+	}
+	if err := output.Put("values", values, no, (ni+2)*ns); err != nil {
+		return err
+	}
+	if err := output.Put("firstOrder", indices.FirstOrder, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("firstOrderLower", indices.FirstOrderLower, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("firstOrderUpper", indices.FirstOrderUpper, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("total", indices.Total, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("totalLower", indices.TotalLower, no, ni); err != nil {
+		return err
+	}
+	if err := output.Put("totalUpper", indices.TotalUpper, no, ni); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func evaluate(config *config.Config, system *system.System,
+	aquantity quantity.Quantity, points []float64) ([]float64, error) {
+
+	approximate, err := database.Open(*approximateFile)
+	if err != nil {
+		return nil, err
+	}
+	defer approximate.Close()
+
+	surrogate := new(solution.Surrogate)
+	if err = approximate.Get("surrogate", surrogate); err != nil {
+		return nil, err
+	}
+
+	target := aquantity
+	if !config.Solution.Aleatory {
+		euncertainty, err := uncertainty.NewEpistemic(system, &config.Uncertainty)
+		if err != nil {
+			return nil, err
+		}
+		target, err = quantity.New(system, euncertainty, &config.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ni, no := target.Dimensions()
+	na, _ := aquantity.Dimensions()
+	np := uint(len(points)) / na
+
+	solution, err := solution.New(ni, no, &config.Solution)
+	if err != nil {
+		return nil, err
+	}
+
+	zi := make([]float64, ni*np)
+	for i := uint(0); i < np; i++ {
+		copy(zi[i*ni:(i+1)*ni], target.Forward(aquantity.Backward(points[i*na:(i+1)*na])))
+	}
+
+	log.Printf("Evaluating the surrogate model at %d points...\n", np)
+
+	return solution.Evaluate(surrogate, zi), nil
+}
@@ -6,6 +6,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math"
+	"math/rand"
 	"strings"
 
 	"github.com/ready-steady/linear"
@@ -14,6 +16,7 @@ import (
 	"github.com/turing-complete/laboratory/src/internal/database"
 	"github.com/turing-complete/laboratory/src/internal/quantity"
 	"github.com/turing-complete/laboratory/src/internal/solution"
+	"github.com/turing-complete/laboratory/src/internal/support"
 	"github.com/turing-complete/laboratory/src/internal/system"
 	"github.com/turing-complete/laboratory/src/internal/uncertainty"
 )
@@ -24,8 +27,16 @@ var (
 	parameterIndex  = flag.String("s", "[]", "the parameters to sweep")
 	defaultNode     = flag.Float64("d", 0.5, "the default value of parameters")
 	nodeCount       = flag.Uint("n", 10, "the number of nodes per parameter")
+	trajectoryCount = flag.Uint("t", 0, "the number of Morris trajectories (screening if positive)")
 )
 
+// step is a move along a Morris trajectory, which changes one parameter by
+// delta.
+type step struct {
+	parameter uint
+	delta     float64
+}
+
 func main() {
 	command.Run(function)
 }
@@ -64,7 +75,14 @@ func function(config *config.Config) error {
 		return err
 	}
 
-	points, err := generate(ni, *nodeCount, config.Solution.Rule, index)
+	var points []float64
+	var steps []step
+	if *trajectoryCount > 0 {
+		points, steps, err = screen(ni, *nodeCount, *trajectoryCount,
+			config.Solution.Rule, index, config.Assessment.Seed)
+	} else {
+		points, err = generate(ni, *nodeCount, config.Solution.Rule, index)
+	}
 	if err != nil {
 		return err
 	}
@@ -106,6 +124,19 @@ func function(config *config.Config) error {
 		return err
 	}
 
+	if *trajectoryCount > 0 {
+		μ, σ := analyze(values, steps, uint(len(index)), no, *trajectoryCount)
+		if err := output.Put("index", index); err != nil {
+			return err
+		}
+		if err := output.Put("muStar", μ, no, uint(len(index))); err != nil {
+			return err
+		}
+		if err := output.Put("sigma", σ, no, uint(len(index))); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -134,6 +165,23 @@ func detect(ni uint, line string) ([]uint, error) {
 func generate(ni, nn uint, rule string, index []uint) ([]float64, error) {
 	steady := []float64{*defaultNode}
 
+	sweep, err := discretize(nn, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := make([][]float64, ni)
+	for i := uint(0); i < ni; i++ {
+		parameters[i] = steady
+	}
+	for _, i := range index {
+		parameters[i] = sweep
+	}
+
+	return linear.TensorFloat64(parameters...), nil
+}
+
+func discretize(nn uint, rule string) ([]float64, error) {
 	sweep := make([]float64, nn)
 	switch rule {
 	case "closed":
@@ -147,14 +195,99 @@ func generate(ni, nn uint, rule string, index []uint) ([]float64, error) {
 	default:
 		return nil, errors.New("the sweep rule is unknown")
 	}
+	return sweep, nil
+}
 
-	parameters := make([][]float64, ni)
-	for i := uint(0); i < ni; i++ {
-		parameters[i] = steady
+// screen generates Morris trajectories. Each trajectory starts at a random
+// point on the grid of nn levels per parameter and moves the parameters in
+// index one at a time in a random order by half of the number of levels. The
+// other parameters are fixed at their default value.
+func screen(ni, nn, nt uint, rule string, index []uint,
+	seed int64) ([]float64, []step, error) {
+
+	if nn < 2 {
+		return nil, nil, errors.New("the number of levels should be at least two")
 	}
+
+	seen := make(map[uint]bool, len(index))
 	for _, i := range index {
-		parameters[i] = sweep
+		if seen[i] {
+			return nil, nil, errors.New(fmt.Sprintf("the index %v is repeated", i))
+		}
+		seen[i] = true
 	}
 
-	return linear.TensorFloat64(parameters...), nil
+	sweep, err := discretize(nn, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	generator := rand.New(rand.NewSource(support.NewSeed(seed)))
+
+	nk, Δ := uint(len(index)), nn/2
+	points := make([]float64, 0, nt*(nk+1)*ni)
+	steps := make([]step, 0, nt*nk)
+
+	current, levels := make([]float64, ni), make([]uint, ni)
+	for t := uint(0); t < nt; t++ {
+		for i := uint(0); i < ni; i++ {
+			current[i] = *defaultNode
+		}
+		for _, i := range index {
+			levels[i] = uint(generator.Intn(int(nn)))
+			current[i] = sweep[levels[i]]
+		}
+		points = append(points, current...)
+
+		for _, k := range generator.Perm(int(nk)) {
+			i := index[k]
+			before := current[i]
+			if levels[i]+Δ < nn {
+				levels[i] += Δ
+			} else {
+				levels[i] -= Δ
+			}
+			current[i] = sweep[levels[i]]
+			points = append(points, current...)
+			steps = append(steps, step{parameter: uint(k), delta: current[i] - before})
+		}
+	}
+
+	return points, steps, nil
+}
+
+// analyze computes the mean of the absolute values and the standard deviation
+// of the elementary effects of each parameter with respect to each output.
+func analyze(values []float64, steps []step, nk, no, nt uint) ([]float64, []float64) {
+	μ, σ := make([]float64, nk*no), make([]float64, nk*no)
+	mean := make([]float64, nk*no)
+
+	effects := make([]float64, nt*nk*no)
+	for t := uint(0); t < nt; t++ {
+		for s := uint(0); s < nk; s++ {
+			step := steps[t*nk+s]
+			i, j := t*(nk+1)+s, t*(nk+1)+s+1
+			for o := uint(0); o < no; o++ {
+				effect := (values[j*no+o] - values[i*no+o]) / step.delta
+				effects[(t*nk+step.parameter)*no+o] = effect
+				μ[step.parameter*no+o] += math.Abs(effect) / float64(nt)
+				mean[step.parameter*no+o] += effect / float64(nt)
+			}
+		}
+	}
+
+	if nt < 2 {
+		return μ, σ
+	}
+	for t := uint(0); t < nt; t++ {
+		for k := uint(0); k < nk*no; k++ {
+			Δ := effects[t*nk*no+k] - mean[k]
+			σ[k] += Δ * Δ / float64(nt-1)
+		}
+	}
+	for k := range σ {
+		σ[k] = math.Sqrt(σ[k])
+	}
+
+	return μ, σ
 }
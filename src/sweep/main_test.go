@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ready-steady/assert"
+)
+
+func TestDetect(t *testing.T) {
+	index, err := detect(3, "[]")
+	assert.Success(err, t)
+	assert.Equal(index, []uint{0, 1, 2}, t)
+
+	index, err = detect(3, "[1, 1]")
+	assert.Success(err, t)
+	assert.Equal(index, []uint{1, 1}, t)
+
+	_, err = detect(3, "[3]")
+	assert.Failure(err, t)
+}
+
+func TestScreenLinear(t *testing.T) {
+	const (
+		ni, nn, nt, no = 3, 4, 5, 2
+	)
+
+	index := []uint{0, 2}
+	nk := uint(len(index))
+
+	points, steps, err := screen(ni, nn, nt, "closed", index, 0)
+	assert.Success(err, t)
+	assert.Equal(uint(len(points)), nt*(nk+1)*ni, t)
+	assert.Equal(uint(len(steps)), nt*nk, t)
+
+	np := uint(len(points)) / ni
+	values := make([]float64, np*no)
+	for i := uint(0); i < np; i++ {
+		x := points[i*ni:]
+		values[i*no+0] = 2*x[0] + 7*x[1] - 3*x[2]
+		values[i*no+1] = -x[0]
+	}
+
+	μ, σ := analyze(values, steps, nk, no, nt)
+	assert.Close(μ, []float64{2, 1, 3, 0}, 1e-12, t)
+	assert.Close(σ, []float64{0, 0, 0, 0}, 1e-12, t)
+}
+
+func TestScreenRepeated(t *testing.T) {
+	_, _, err := screen(3, 4, 5, "closed", []uint{1, 1}, 0)
+	assert.Failure(err, t)
+}
+
+func TestAnalyze(t *testing.T) {
+	values := []float64{0, 1, 1, 0}
+	steps := []step{{parameter: 0, delta: 0.5}, {parameter: 0, delta: 0.5}}
+
+	μ, σ := analyze(values, steps, 1, 1, 2)
+	assert.Equal(μ, []float64{2}, t)
+	assert.Equal(σ, []float64{math.Sqrt(8)}, t)
+}